@@ -0,0 +1,79 @@
+package calculator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/config"
+	"github.com/sporadisk/clocker/format"
+)
+
+// BreakPolicy describes categories that are logged, but not counted as work.
+type BreakPolicy struct {
+	Categories []string                 // categories that are not counted as work
+	Paid       map[string]time.Duration // the part of a break category that is paid, per day
+	Export     bool                     // export break events along with the others
+}
+
+// LoadBreakPolicy reads the break policy from the calculator config.
+func LoadBreakPolicy(conf *config.CalcConfig) (BreakPolicy, error) {
+	policy := BreakPolicy{
+		Paid: map[string]time.Duration{},
+	}
+
+	if conf == nil {
+		return policy, nil
+	}
+
+	for _, cat := range conf.BreakCategories {
+		policy.Categories = append(policy.Categories, format.CleanParam(cat))
+	}
+
+	for cat, allowance := range conf.PaidBreaks {
+		cat = format.CleanParam(cat)
+		if !policy.IsBreak(cat) {
+			return policy, fmt.Errorf("paid break category %q is not listed in breakCategories", cat)
+		}
+
+		d, err := format.ParseDuration(allowance)
+		if err != nil {
+			return policy, fmt.Errorf("invalid paid break duration for %q: %w", cat, err)
+		}
+		policy.Paid[cat] = d
+	}
+
+	policy.Export = conf.ExportBreaks
+	return policy, nil
+}
+
+// IsBreak checks if time logged in the category is break time.
+func (bp *BreakPolicy) IsBreak(category string) bool {
+	for _, c := range bp.Categories {
+		if strings.EqualFold(c, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// paidBreakKey identifies the paid break time of a category on a log day.
+type paidBreakKey struct {
+	date     string
+	category string
+}
+
+// addBreak records time logged in a break category, and returns the part of it
+// that counts as work. The paid allowance applies to each log day.
+func (ls *LogSummary) addBreak(category string, dur time.Duration) (paid time.Duration) {
+	key := paidBreakKey{date: ls.currentDate.String(), category: format.CleanParam(category)}
+	allowance := ls.Breaks.Paid[key.category]
+	remaining := allowance - ls.paidBreakTime[key]
+	if remaining > 0 {
+		paid = min(dur, remaining)
+		ls.paidBreakTime[key] += paid
+	}
+
+	ls.breakTime += dur
+	return paid
+}
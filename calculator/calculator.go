@@ -1,13 +1,18 @@
 package calculator
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/sporadisk/clocker/client/report"
 	"github.com/sporadisk/clocker/config"
 	"github.com/sporadisk/clocker/console"
 	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/history"
+	"github.com/sporadisk/clocker/locale"
 	"github.com/sporadisk/clocker/logentry"
 	"github.com/sporadisk/clocker/parameter"
 	"github.com/sporadisk/clocker/summary"
@@ -15,11 +20,19 @@ import (
 
 type Calculator struct {
 	Conf              *config.Config
-	EventExporter     event.Exporter
+	ExportTargets     []*ExportTarget
 	Subscriber        logentry.Subscriber
 	SummaryOutput     summary.Output
+	ReportWriter      *report.Writer
 	DefaultFullDay    time.Duration
 	CategoryParseMode string
+	Breaks            BreakPolicy
+	Policy            WorkPolicy
+	Limits            *Limits
+	Rates             RateTable
+	EventProcessing   *EventProcessing
+	History           *history.Store
+	Locale            *locale.Locale
 
 	eventInbox chan inboxEvent
 }
@@ -30,14 +43,15 @@ func (c *Calculator) Start() error {
 		return fmt.Errorf("c.getDefaultFullDay: %w", err)
 	}
 
-	// Initialize the exporter, if one has been configured
-	if c.Conf.Exporter != nil {
-		exporter, err := LoadExporter(c.Conf.Exporter)
-		if err != nil {
-			return fmt.Errorf("Error loading exporter: %w", err)
-		}
+	// Initialize the exporters, if any have been configured
+	c.ExportTargets, err = LoadExportTargets(c.Conf)
+	if err != nil {
+		return fmt.Errorf("Error loading exporters: %w", err)
+	}
 
-		c.EventExporter = exporter
+	err = c.LoadLocale()
+	if err != nil {
+		return fmt.Errorf("LoadLocale: %w", err)
 	}
 
 	err = c.LoadSummaryOutput()
@@ -45,6 +59,11 @@ func (c *Calculator) Start() error {
 		return fmt.Errorf("LoadSummaryOutput: %w", err)
 	}
 
+	err = c.LoadReportWriter()
+	if err != nil {
+		return fmt.Errorf("LoadReportWriter: %w", err)
+	}
+
 	if c.Conf.Calc != nil && c.Conf.Calc.CategoryParseMode != "" {
 		parseMode, err := parameter.Validate(c.Conf.Calc.CategoryParseMode, []string{"v1", "v2"})
 		if err != nil {
@@ -53,6 +72,38 @@ func (c *Calculator) Start() error {
 		c.CategoryParseMode = parseMode
 	}
 
+	c.Breaks, err = LoadBreakPolicy(c.Conf.Calc)
+	if err != nil {
+		return fmt.Errorf("LoadBreakPolicy: %w", err)
+	}
+
+	c.Policy, err = LoadWorkPolicy(c.Conf.Calc)
+	if err != nil {
+		return fmt.Errorf("LoadWorkPolicy: %w", err)
+	}
+
+	c.Rates, err = LoadRateTable(c.Conf.Calc)
+	if err != nil {
+		return fmt.Errorf("LoadRateTable: %w", err)
+	}
+
+	c.EventProcessing, err = LoadEventProcessing(c.Conf.Calc)
+	if err != nil {
+		return fmt.Errorf("LoadEventProcessing: %w", err)
+	}
+
+	c.Limits, err = LoadLimits(c.Conf.Calc)
+	if err != nil {
+		return fmt.Errorf("LoadLimits: %w", err)
+	}
+
+	if c.Limits != nil {
+		c.History, err = history.Open(c.Conf.Calc.Limits.HistoryFile)
+		if err != nil {
+			return fmt.Errorf("history.Open: %w", err)
+		}
+	}
+
 	// Start the inbox processing goroutine
 	c.eventInbox = make(chan inboxEvent, 100)
 	go func() {
@@ -88,18 +139,57 @@ func (c *Calculator) getDefaultFullDay() error {
 	return nil
 }
 
+// AskAndExport asks for confirmation, and exports the events to each of the
+// export targets in turn. A failed export doesn't stop the export to the
+// remaining targets.
 func (c *Calculator) AskAndExport(summaryEvents []*event.Event) error {
-	if !console.Confirm(fmt.Sprintf("Export log events to %s?", c.Conf.Exporter.Name)) {
-		fmt.Println("Export denied.")
-		return nil
+	results := make([]string, 0, len(c.ExportTargets))
+	var errs []error
+
+	for _, target := range c.ExportTargets {
+		result, err := c.exportTo(target, summaryEvents)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.Label, err))
+			result = c.Locale.Sprintf("failed: %s", err.Error())
+		}
+		results = append(results, fmt.Sprintf(" - %s: %s", target.Label, result))
 	}
 
-	fmt.Println("Export started.")
-	err := c.EventExporter.Export(summaryEvents)
+	if len(c.ExportTargets) > 1 {
+		fmt.Println(c.Locale.Sprintf("Export results:"))
+		for _, r := range results {
+			fmt.Println(r)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Calculator) exportTo(target *ExportTarget, summaryEvents []*event.Event) (result string, err error) {
+	events := target.Events(summaryEvents)
+	if len(events) == 0 {
+		return c.Locale.Sprintf("no matching events"), nil
+	}
+
+	if target.Rounding != nil {
+		raw := event.TotalDuration(events)
+		events = target.Rounding.Apply(events)
+		fmt.Println(c.Locale.Sprintf("Rounded to %s (%s): %s raw, %s rounded",
+			format.DurationHM(target.Rounding.Increment), target.Rounding.Mode,
+			format.DurationHM(raw), format.DurationHM(event.TotalDuration(events))))
+	}
+
+	if !console.Confirm(c.Locale.Sprintf("Export %d log events (%s) to %s?", len(events), format.DurationHM(event.TotalDuration(events)), target.Label)) {
+		fmt.Println(c.Locale.Sprintf("Export denied."))
+		return c.Locale.Sprintf("denied"), nil
+	}
+
+	fmt.Println(c.Locale.Sprintf("Export started."))
+	err = target.Exporter.Export(events)
 	if err != nil {
-		return fmt.Errorf("EventExporter.Export: %w", err)
+		return "", fmt.Errorf("Exporter.Export: %w", err)
 	}
-	fmt.Println("Export completed.")
+	fmt.Println(c.Locale.Sprintf("Export completed."))
 
-	return nil
+	return c.Locale.Sprintf("exported %d events", len(events)), nil
 }
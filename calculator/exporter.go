@@ -1,75 +1,137 @@
 package calculator
 
 import (
-	"context"
 	"fmt"
-	"strconv"
+	"path"
 	"strings"
 
-	"github.com/sporadisk/clocker/client/timely"
 	"github.com/sporadisk/clocker/config"
 	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/format"
 )
 
+// ExportTarget is a configured exporter, and the categories of events that are
+// sent to it.
+type ExportTarget struct {
+	Label      string
+	Categories []string // patterns matched against the event category, empty for every category
+	Exporter   event.Exporter
+	Rounding   *event.Rounding
+}
+
+// LoadExporter creates the configured exporter from the exporter registry.
 func LoadExporter(conf *config.ExporterConfig) (event.Exporter, error) {
-	switch strings.ToLower(conf.Name) {
-	case "timely":
-		return TimelyExporter(conf.Params)
-	default:
-		return nil, fmt.Errorf("unrecognized exporter: %s", conf.Name)
+	exporter, err := event.NewExporter(conf.Name, conf.Params)
+	if err != nil {
+		return nil, fmt.Errorf("event.NewExporter: %w", err)
 	}
+
+	return exporter, nil
 }
 
-func getParams(params map[string]string, required ...string) (map[string]string, error) {
-	result := make(map[string]string)
-	for _, key := range required {
-		value, ok := params[key]
-		if !ok {
-			return nil, fmt.Errorf("missing parameter: %s", key)
+// LoadExportTargets creates the export targets for all the configured
+// exporters.
+func LoadExportTargets(conf *config.Config) ([]*ExportTarget, error) {
+	var targets []*ExportTarget
+	labels := map[string]bool{}
+
+	for i, ec := range conf.ExporterConfigs() {
+		target := &ExportTarget{
+			Label: ec.Label,
+		}
+		if target.Label == "" {
+			target.Label = ec.Name
+		}
+
+		if labels[target.Label] {
+			return nil, fmt.Errorf("exporters[%d]: the label %q is used by more than one exporter", i, target.Label)
+		}
+		labels[target.Label] = true
+
+		for _, pattern := range ec.Categories {
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			_, err := path.Match(pattern, "")
+			if err != nil {
+				return nil, fmt.Errorf("exporters[%d]: invalid category pattern %q: %w", i, pattern, err)
+			}
+			target.Categories = append(target.Categories, pattern)
+		}
+
+		var err error
+		target.Rounding, err = LoadRounding(ec.Rounding)
+		if err != nil {
+			return nil, fmt.Errorf("exporters[%d]: LoadRounding: %w", i, err)
+		}
+
+		target.Exporter, err = LoadExporter(ec)
+		if err != nil {
+			return nil, fmt.Errorf("exporters[%d]: LoadExporter: %w", i, err)
 		}
-		result[key] = value
+
+		targets = append(targets, target)
 	}
 
-	return result, nil
+	return targets, nil
 }
 
-func TimelyExporter(params map[string]string) (*timely.Client, error) {
-	p, err := getParams(params, "applicationId", "secret", "callbackUrl")
-	if err != nil {
-		return nil, fmt.Errorf("getParams: %w", err)
+// Match checks if events in the category are sent to the target.
+func (t *ExportTarget) Match(category string) bool {
+	if len(t.Categories) == 0 {
+		return true
 	}
 
-	client := &timely.Client{
-		ApplicationID: p["applicationId"],
-		ClientSecret:  p["secret"],
-		CallbackURL:   p["callbackUrl"],
+	category = strings.ToLower(category)
+	for _, pattern := range t.Categories {
+		if ok, _ := path.Match(pattern, category); ok {
+			return true
+		}
 	}
+	return false
+}
 
-	// accountId is optional
-	accountID, ok := params["accountId"]
-	if ok {
-		accountIDInt, err := strconv.Atoi(accountID)
-		if err != nil {
-			return nil, fmt.Errorf("can't parse accountId as int: %w", err)
+// Events returns the events that are sent to the target.
+func (t *ExportTarget) Events(events []*event.Event) []*event.Event {
+	var matched []*event.Event
+	for _, e := range events {
+		if t.Match(e.Category) {
+			matched = append(matched, e)
 		}
-		client.AccountID = accountIDInt
+	}
+	return matched
+}
+
+// LoadRounding reads the rounding policy of an exporter. It returns nil if the
+// exporter doesn't round.
+func LoadRounding(conf *config.RoundingConfig) (*event.Rounding, error) {
+	if conf == nil {
+		return nil, nil
+	}
+
+	r := &event.Rounding{
+		Mode:       strings.ToLower(conf.Mode),
+		Compensate: conf.Compensate,
+	}
+	if r.Mode == "" {
+		r.Mode = event.RoundNearest
+	}
+
+	var err error
+	r.Increment, err = format.ParseDuration(conf.Increment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rounding increment: %w", err)
 	}
 
-	// projectId is required, but we handle that in the client, to make it
-	// easier to find a project-id to use.
-	projectId, ok := params["projectId"]
-	if ok {
-		projectIDInt, err := strconv.Atoi(projectId)
+	if conf.Minimum != "" {
+		r.Minimum, err = format.ParseDuration(conf.Minimum)
 		if err != nil {
-			return nil, fmt.Errorf("can't parse projectId as int: %w", err)
+			return nil, fmt.Errorf("invalid rounding minimum: %w", err)
 		}
-		client.ProjectID = projectIDInt
 	}
 
-	err = client.Init(context.Background())
+	err = r.Validate()
 	if err != nil {
-		return nil, fmt.Errorf("timely.Client.Init: %w", err)
+		return nil, fmt.Errorf("r.Validate: %w", err)
 	}
 
-	return client, nil
+	return r, nil
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sporadisk/clocker/client/report"
+	"github.com/sporadisk/clocker/event"
 	"github.com/sporadisk/clocker/logentry"
 )
 
@@ -33,31 +35,98 @@ func (c *Calculator) WaitForEntries() error {
 	}
 }
 
+// Process sums up the log entries. Logs that span several days, such as the
+// ones read by the org, timeclock and Timewarrior dialects, are split at their
+// date entries and summed up one day at a time.
 func (c *Calculator) Process(entries []logentry.Entry) error {
+	var days []report.Day
+	var exportEvents []*event.Event
+	today := time.Now().Format("2006-01-02")
+
+	for _, dayEntries := range splitDays(entries) {
+		day, err := c.processDay(dayEntries)
+		if err != nil {
+			return err
+		}
+
+		if day.Summary.Valid {
+			days = append(days, day)
+		}
+
+		// don't try to export if the summary is for today
+		// (as a rule, the log for today is probably incomplete)
+		if day.Summary.Date != nil && today != day.Summary.Date.String() {
+			exportEvents = append(exportEvents, day.Events...)
+		}
+	}
+
+	if c.ReportWriter != nil && len(days) > 0 {
+		path, err := c.ReportWriter.Write(days)
+		if err != nil {
+			return fmt.Errorf("ReportWriter.Write: %w", err)
+		}
+		fmt.Println(c.Locale.Sprintf("Report written to %s", path))
+	}
+
+	if len(c.ExportTargets) > 0 && len(exportEvents) > 0 {
+		err := c.AskAndExport(exportEvents)
+		if err != nil {
+			return fmt.Errorf("AskAndExport: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// processDay sums up and outputs the entries of a single day.
+func (c *Calculator) processDay(entries []logentry.Entry) (report.Day, error) {
 	summary := &LogSummary{
 		Entries:      entries,
 		FullDay:      c.DefaultFullDay,
 		CatParseMode: c.CategoryParseMode,
+		Breaks:       c.Breaks,
+		Policy:       c.Policy,
+		Rates:        c.Rates,
+		Locale:       c.Locale,
 	}
 
 	summaryResult, summaryEvents := summary.Sum()
 
+	if c.Limits != nil && summaryResult.Valid && summaryResult.Date != nil {
+		warnings, err := c.Limits.Check(historyDay(summaryResult, summaryEvents), c.History, c.Locale)
+		if err != nil {
+			return report.Day{}, fmt.Errorf("Limits.Check: %w", err)
+		}
+		summaryResult.Warnings = append(summaryResult.Warnings, warnings...)
+	}
+
+	if c.EventProcessing != nil {
+		summaryEvents = c.EventProcessing.ProcessSummary(&summaryResult, summaryEvents)
+	}
+
 	err := c.SummaryOutput.OutputSummary(summaryResult)
 	if err != nil {
-		return fmt.Errorf("SummaryOutput.Output: %w", err)
+		return report.Day{}, fmt.Errorf("SummaryOutput.Output: %w", err)
 	}
 
-	today := time.Now().Format("2006-01-02")
-	// don't try to export if the summary is for today
-	// (as a rule, the log for today is probably incomplete)
-	canExport := (today != summaryResult.Date.String())
+	return report.Day{Summary: summaryResult, Events: summaryEvents}, nil
+}
 
-	if c.EventExporter != nil && canExport {
-		err := c.AskAndExport(summaryEvents)
-		if err != nil {
-			return fmt.Errorf("AskAndExport: %w", err)
+// splitDays splits the entries at each date entry after the first, so that
+// the entries before a log's first date entry stay with its first day.
+func splitDays(entries []logentry.Entry) [][]logentry.Entry {
+	days := [][]logentry.Entry{}
+	start := 0
+	dated := false
+	for i, entry := range entries {
+		if entry.Action != logentry.ActionSetDay {
+			continue
+		}
+		if dated {
+			days = append(days, entries[start:i])
+			start = i
 		}
+		dated = true
 	}
-
-	return nil
+	return append(days, entries[start:])
 }
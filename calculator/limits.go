@@ -0,0 +1,145 @@
+package calculator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sporadisk/clocker/config"
+	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/history"
+	"github.com/sporadisk/clocker/locale"
+	"github.com/sporadisk/clocker/summary"
+)
+
+// Limits describes the maximum working time per day and week, and the minimum
+// rest period between days. The weekly and rest period checks rely on the
+// history of previously processed days.
+type Limits struct {
+	MaxDay  time.Duration // 0 for no limit
+	MaxWeek time.Duration // 0 for no limit
+	MinRest time.Duration // 0 for no limit
+}
+
+// LoadLimits reads the working time limits from the calculator config. It
+// returns nil if no limits have been configured.
+func LoadLimits(conf *config.CalcConfig) (*Limits, error) {
+	if conf == nil || conf.Limits == nil {
+		return nil, nil
+	}
+
+	limits := &Limits{}
+	durations := []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"maxDay", conf.Limits.MaxDay, &limits.MaxDay},
+		{"maxWeek", conf.Limits.MaxWeek, &limits.MaxWeek},
+		{"minRest", conf.Limits.MinRest, &limits.MinRest},
+	}
+
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+
+		parsed, err := format.ParseDuration(d.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s duration: %w", d.name, err)
+		}
+		*d.dst = parsed
+	}
+
+	return limits, nil
+}
+
+// Check records the day in the history, and checks it against the limits. The
+// warnings are translated with the locale.
+func (l *Limits) Check(day history.Day, store *history.Store, loc *locale.Locale) ([]string, error) {
+	err := store.Record(day)
+	if err != nil {
+		return nil, fmt.Errorf("store.Record: %w", err)
+	}
+
+	var warnings []string
+	if l.MaxDay > 0 && day.Worked > l.MaxDay {
+		warnings = append(warnings, loc.Sprintf("Worked %s on %s: The daily maximum is %s",
+			format.DurationHM(day.Worked), day.Date, format.DurationHM(l.MaxDay)))
+	}
+
+	if l.MaxWeek > 0 {
+		week, err := store.Week(day.Date)
+		if err != nil {
+			return nil, fmt.Errorf("store.Week: %w", err)
+		}
+
+		weekWorked := time.Duration(0)
+		for _, d := range week {
+			weekWorked += d.Worked
+		}
+
+		if weekWorked > l.MaxWeek {
+			warnings = append(warnings, loc.Sprintf("Worked %s in the week of %s: The weekly maximum is %s",
+				format.DurationHM(weekWorked), day.Date, format.DurationHM(l.MaxWeek)))
+		}
+	}
+
+	if l.MinRest > 0 {
+		prev, ok := store.Previous(day.Date)
+		if ok {
+			warnings = append(warnings, l.checkRest(prev, day, loc)...)
+		}
+
+		next, ok := store.Next(day.Date)
+		if ok {
+			warnings = append(warnings, l.checkRest(day, next, loc)...)
+		}
+	}
+
+	return warnings, nil
+}
+
+func (l *Limits) checkRest(before, after history.Day, loc *locale.Locale) []string {
+	if before.LastOut.IsZero() || after.FirstIn.IsZero() {
+		return nil
+	}
+
+	rest := after.FirstIn.Sub(before.LastOut)
+	if rest >= l.MinRest || rest < 0 {
+		return nil
+	}
+
+	return []string{loc.Sprintf("Only %s of rest between %s %s and %s %s: The minimum is %s",
+		format.DurationHM(rest),
+		before.Date, format.Timestamp(before.LastOut),
+		after.Date, format.Timestamp(after.FirstIn),
+		format.DurationHM(l.MinRest),
+	)}
+}
+
+// historyDay creates the history record for a summarized day. The event
+// timestamps are dated, so the rest periods can be measured across days.
+// Flex and paid breaks count toward the day's target, but aren't time worked.
+func historyDay(sum summary.Summary, events []*event.Event) history.Day {
+	day := history.Day{
+		Date:   sum.Date.String(),
+		Worked: sum.TimeWorked - sum.FlexUsed + sum.FlexEarned - sum.PaidBreakTime,
+	}
+
+	for _, e := range events {
+		if e.Start.IsZero() || e.End.IsZero() {
+			continue
+		}
+
+		if day.FirstIn.IsZero() || e.Start.Before(day.FirstIn) {
+			day.FirstIn = e.Start
+		}
+
+		if e.End.After(day.LastOut) {
+			day.LastOut = e.End
+		}
+	}
+
+	return day
+}
@@ -1,12 +1,12 @@
 package calculator
 
 import (
-	"fmt"
 	"strings"
 	"time"
 
 	"github.com/sporadisk/clocker/event"
 	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/locale"
 	"github.com/sporadisk/clocker/logentry"
 	"github.com/sporadisk/clocker/summary"
 )
@@ -25,6 +25,10 @@ type LogSummary struct {
 	Entries      []logentry.Entry
 	FullDay      time.Duration
 	CatParseMode string
+	Breaks       BreakPolicy
+	Policy       WorkPolicy
+	Rates        RateTable
+	Locale       *locale.Locale // translates the warnings
 
 	logState         string
 	lastOn           time.Time
@@ -34,8 +38,16 @@ type LogSummary struct {
 	prevCommand      string
 	currentCategory  string
 	currentTask      string
+	currentNote      string
 	currentDate      summary.Date
 	events           []*event.Event
+	sessions         []session
+	pendingRange     *logentry.Entry
+	flexUsed         time.Duration
+	flexEarned       time.Duration
+	flexDays         int
+	breakTime        time.Duration
+	paidBreakTime    map[paidBreakKey]time.Duration
 }
 
 func (ls *LogSummary) Sum() (summary.Summary, []*event.Event) {
@@ -47,6 +59,13 @@ func (ls *LogSummary) Sum() (summary.Summary, []*event.Event) {
 	ls.taskCatDurations = map[string]time.Duration{}
 	ls.prevCommand = "--start of document--"
 	ls.events = []*event.Event{}
+	ls.sessions = []session{}
+	ls.pendingRange = nil
+	ls.flexUsed = 0
+	ls.flexEarned = 0
+	ls.flexDays = 0
+	ls.breakTime = 0
+	ls.paidBreakTime = map[paidBreakKey]time.Duration{}
 
 	if ls.CatParseMode != "" {
 		ls.CatParseMode = format.CleanParam(ls.CatParseMode)
@@ -57,7 +76,7 @@ func (ls *LogSummary) Sum() (summary.Summary, []*event.Event) {
 	if len(ls.Entries) == 0 {
 		return summary.Summary{
 			Valid:         false,
-			ValidationMsg: "No valid time entries detected.",
+			ValidationMsg: ls.Locale.Sprintf("No valid time entries detected."),
 		}, nil
 	}
 
@@ -84,6 +103,25 @@ func (ls *LogSummary) Sum() (summary.Summary, []*event.Event) {
 			}
 		}
 
+		if entry.Action == logentry.ActionRangeStart {
+			success, res := ls.rangeStart(entry)
+			if !success {
+				return res, nil
+			}
+		}
+
+		if entry.Action == logentry.ActionRangeEnd {
+			success, res := ls.rangeEnd(entry)
+			if !success {
+				return res, nil
+			}
+		}
+
+		if entry.Action == logentry.ActionTaskDuration {
+			category, task := ls.parseCategoryAndTask(entry.Task)
+			ls.taskDuration(entry, category, task)
+		}
+
 		if entry.Action == logentry.ActionFlex {
 			success, res := ls.flex(entry)
 			if !success {
@@ -91,11 +129,18 @@ func (ls *LogSummary) Sum() (summary.Summary, []*event.Event) {
 			}
 		}
 
+		if entry.Action == logentry.ActionFlexDay {
+			success, res := ls.flexDay(entry)
+			if !success {
+				return res, nil
+			}
+		}
+
 		if entry.Action == logentry.ActionTarget {
 			if ls.logState == stateOn {
 				return summary.Summary{
 					Valid:         false,
-					ValidationMsg: fmt.Sprintf(`"%s" entry on line %d follows a clock-in, which is wrong.`, entry.Command, entry.LineNumber),
+					ValidationMsg: ls.Locale.Sprintf(`"%s" entry on line %d follows a clock-in, which is wrong.`, entry.Command, entry.LineNumber),
 				}, nil
 			}
 
@@ -104,6 +149,9 @@ func (ls *LogSummary) Sum() (summary.Summary, []*event.Event) {
 		}
 
 		if entry.Action == logentry.ActionSetDay {
+			// timestamps are relative to the current day, so the previous
+			// clock-out doesn't constrain the clock-ins of a new day
+			ls.lastOff = time.Time{}
 			ls.currentDate = summary.Date{
 				DayName: entry.DayName,
 				Day:     entry.Day,
@@ -123,35 +171,73 @@ func (ls *LogSummary) clockOut(entry logentry.Entry) (success bool, result summa
 	if ls.logState != stateOn {
 		return false, summary.Summary{
 			Valid:         false,
-			ValidationMsg: fmt.Sprintf(`Clock-out at %s on line %d follows "%s", should follow a clock-in`, entry.Timestamp.Format(timestampFormat), entry.LineNumber, ls.logState),
+			ValidationMsg: ls.Locale.Sprintf(`Clock-out at %s on line %d follows "%s", should follow a clock-in`, entry.Timestamp.Format(timestampFormat), entry.LineNumber, ls.logState),
 		}
 	}
 
 	if !entry.Timestamp.After(ls.lastOn) {
 		return false, summary.Summary{
 			Valid:         false,
-			ValidationMsg: fmt.Sprintf(`Clock-out on line %d has an earlier timestamp than its corresponding "%s"`, entry.LineNumber, ls.prevCommand),
+			ValidationMsg: ls.Locale.Sprintf(`Clock-out on line %d has an earlier timestamp than its corresponding "%s"`, entry.LineNumber, ls.prevCommand),
 		}
 	}
 
+	success, res := ls.addSession(entry, ls.lastOn, *entry.Timestamp, ls.currentCategory, ls.currentTask, ls.currentNote)
+	if !success {
+		return false, res
+	}
+
 	ls.lastOff = *entry.Timestamp
 	ls.logState = stateOff
-	ls.durations = append(ls.durations, entry.Timestamp.Sub(ls.lastOn))
 
-	if ls.currentCategory != "" {
-		ls.addToCategory(ls.currentCategory, entry.Timestamp.Sub(ls.lastOn))
+	// reset current task and category
+	ls.currentTask = ""
+	ls.currentCategory = ""
+	ls.currentNote = ""
+
+	return true, summary.Summary{}
+}
+
+// session is a span of logged time, with full timestamps.
+type session struct {
+	start      time.Time
+	end        time.Time
+	lineNumber int
+	working    bool   // false for time logged in break categories
+	category   string // as counted in the category totals
+	event      *event.Event
+}
+
+// addSession records the time between `from` and `to` (log-entry timestamps)
+// as worked time, and creates the corresponding event. Sessions may not
+// overlap each other.
+func (ls *LogSummary) addSession(entry logentry.Entry, from, to time.Time, category, task, note string) (success bool, result summary.Summary) {
+	start := eventTimeStamp(ls.currentDate, from)
+	end := eventTimeStamp(ls.currentDate, to)
+
+	for _, s := range ls.sessions {
+		if start.Before(s.end) && s.start.Before(end) {
+			return false, summary.Summary{
+				Valid: false,
+				ValidationMsg: ls.Locale.Sprintf(`The time from %s to %s ending on line %d overlaps the time from %s to %s logged on line %d`,
+					from.Format(timestampFormat), to.Format(timestampFormat), entry.LineNumber,
+					s.start.Format(timestampFormat), s.end.Format(timestampFormat), s.lineNumber,
+				),
+			}
+		}
 	}
 
-	eventCategory := ls.currentCategory
+	eventCategory := category
 	if eventCategory == "" {
 		eventCategory = summary.Uncategorized
 	}
 
 	event := &event.Event{
-		Start:    eventTimeStamp(ls.currentDate, ls.lastOn),
-		End:      eventTimeStamp(ls.currentDate, ls.lastOff),
+		Start:    start,
+		End:      end,
 		Category: eventCategory,
-		Task:     ls.currentTask,
+		Task:     task,
+		Note:     note,
 		Date: event.EventDate{
 			Day:   ls.currentDate.Day,
 			Month: ls.currentDate.Month,
@@ -159,23 +245,82 @@ func (ls *LogSummary) clockOut(entry logentry.Entry) (success bool, result summa
 		},
 	}
 	event.DetermineHours()
+
+	ls.sessions = append(ls.sessions, session{
+		start:      start,
+		end:        end,
+		lineNumber: entry.LineNumber,
+		working:    !ls.Breaks.IsBreak(category),
+		category:   category,
+		event:      event,
+	})
+
+	dur := to.Sub(from)
+	if ls.Breaks.IsBreak(category) {
+		ls.durations = append(ls.durations, ls.addBreak(category, dur))
+		if !ls.Breaks.Export {
+			return true, summary.Summary{}
+		}
+	} else {
+		ls.durations = append(ls.durations, dur)
+		ls.addToCategory(category, dur)
+	}
+
 	ls.events = append(ls.events, event)
 
-	// reset current task and category
-	ls.currentTask = ""
-	ls.currentCategory = ""
+	return true, summary.Summary{}
+}
+
+// rangeStart begins an explicit time range. Ranges don't affect the clock-in
+// state, so they can be used to backfill time between point entries.
+func (ls *LogSummary) rangeStart(entry logentry.Entry) (success bool, result summary.Summary) {
+	if ls.pendingRange != nil {
+		return false, summary.Summary{
+			Valid:         false,
+			ValidationMsg: ls.Locale.Sprintf(`Time range on line %d starts before the range on line %d has ended`, entry.LineNumber, ls.pendingRange.LineNumber),
+		}
+	}
 
+	ls.pendingRange = &entry
 	return true, summary.Summary{}
 }
 
+func (ls *LogSummary) rangeEnd(entry logentry.Entry) (success bool, result summary.Summary) {
+	start := ls.pendingRange
+	if start == nil {
+		return false, summary.Summary{
+			Valid:         false,
+			ValidationMsg: ls.Locale.Sprintf(`Time range on line %d ends without having started`, entry.LineNumber),
+		}
+	}
+	ls.pendingRange = nil
+
+	// the open session will be recorded at the next clock-out, but a range
+	// ending after its clock-in would overlap it
+	if ls.logState == stateOn && entry.Timestamp.After(ls.lastOn) {
+		return false, summary.Summary{
+			Valid: false,
+			ValidationMsg: ls.Locale.Sprintf(`Time range on line %d overlaps the clock-in at %s`,
+				entry.LineNumber,
+				ls.lastOn.Format(timestampFormat),
+			),
+		}
+	}
+
+	category, task := ls.parseCategoryAndTask(start.Task)
+	return ls.addSession(entry, *start.Timestamp, *entry.Timestamp, category, task, start.Note)
+}
+
 // the log-entry timestamps lack a date, so we need to supply that
 func eventTimeStamp(d summary.Date, t time.Time) time.Time {
 	// get the current local timestamp, in order to use its location
 	now := time.Now()
 
 	// construct a new time.Time with the date from `d`, the time from `t` and
-	// the timezone from `now`
-	return time.Date(d.Year, time.Month(d.Month), d.Day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), now.Location())
+	// the timezone from `now`. Timestamps past midnight (24:00) carry a day
+	// offset from the zero date in `t`.
+	dayOffset := t.YearDay() - 1
+	return time.Date(d.Year, time.Month(d.Month), d.Day+dayOffset, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), now.Location())
 }
 
 func (ls *LogSummary) addToCategory(cat string, dur time.Duration) {
@@ -189,20 +334,23 @@ func (ls *LogSummary) addToCategory(cat string, dur time.Duration) {
 	}
 
 	ls.taskCatDurations[cat] += dur
+	if ls.taskCatDurations[cat] <= 0 {
+		delete(ls.taskCatDurations, cat) // deducted entirely
+	}
 }
 
 func (ls *LogSummary) clockIn(entry logentry.Entry) (success bool, result summary.Summary) {
 	if ls.logState == stateOn {
 		return false, summary.Summary{
 			Valid:         false,
-			ValidationMsg: fmt.Sprintf(`Duplicate clock-in on line %d`, entry.LineNumber),
+			ValidationMsg: ls.Locale.Sprintf(`Duplicate clock-in on line %d`, entry.LineNumber),
 		}
 	}
 
 	if entry.Timestamp.Before(ls.lastOff) && !ls.lastOff.IsZero() {
 		return false, summary.Summary{
 			Valid: false,
-			ValidationMsg: fmt.Sprintf(`Clock-in at %s on line %d occurs prior to the previous clock-out (%s)`,
+			ValidationMsg: ls.Locale.Sprintf(`Clock-in at %s on line %d occurs prior to the previous clock-out (%s)`,
 				entry.Timestamp.Format(timestampFormat),
 				entry.LineNumber,
 				ls.lastOff.Format(timestampFormat),
@@ -228,26 +376,83 @@ func (ls *LogSummary) startTask(entry logentry.Entry, category, task string) (su
 
 	ls.currentTask = task
 	ls.currentCategory = category
+	ls.currentNote = entry.Note
 
 	return ls.clockIn(entry)
 }
 
+// taskDuration records time spent on a task without clock times. It counts
+// toward the day like flex time, but also toward its category, and is exported
+// as an event without a start or end.
+func (ls *LogSummary) taskDuration(entry logentry.Entry, category, task string) {
+	if ls.Breaks.IsBreak(category) {
+		ls.durations = append(ls.durations, ls.addBreak(category, *entry.Duration))
+		if !ls.Breaks.Export {
+			return
+		}
+	} else {
+		ls.durations = append(ls.durations, *entry.Duration)
+		ls.addToCategory(category, *entry.Duration)
+	}
+
+	eventCategory := category
+	if eventCategory == "" {
+		eventCategory = summary.Uncategorized
+	}
+
+	event := &event.Event{
+		Category: eventCategory,
+		Task:     task,
+		Note:     entry.Note,
+		Date: event.EventDate{
+			Day:   ls.currentDate.Day,
+			Month: ls.currentDate.Month,
+			Year:  ls.currentDate.Year,
+		},
+	}
+	event.SetDuration(*entry.Duration)
+	ls.events = append(ls.events, event)
+}
+
 func (ls *LogSummary) flex(entry logentry.Entry) (success bool, result summary.Summary) {
 	if ls.logState == stateOn {
 		return false, summary.Summary{
 			Valid:         false,
-			ValidationMsg: fmt.Sprintf(`Flex time entry on line %d follows a clock-in, which is wrong.`, entry.LineNumber),
+			ValidationMsg: ls.Locale.Sprintf(`Flex time entry on line %d follows a clock-in, which is wrong.`, entry.LineNumber),
 		}
 	}
 
 	ls.logState = stateFlex
 	ls.durations = append(ls.durations, *entry.Duration)
+
+	if *entry.Duration < 0 {
+		ls.flexEarned += -*entry.Duration
+	} else {
+		ls.flexUsed += *entry.Duration
+	}
+
+	return true, summary.Summary{}
+}
+
+// flexDay covers the day's target with flex time. The target is applied when
+// summarizing, since it may be changed by a later entry.
+func (ls *LogSummary) flexDay(entry logentry.Entry) (success bool, result summary.Summary) {
+	if ls.logState == stateOn {
+		return false, summary.Summary{
+			Valid:         false,
+			ValidationMsg: ls.Locale.Sprintf(`Flex day entry on line %d follows a clock-in, which is wrong.`, entry.LineNumber),
+		}
+	}
+
+	ls.logState = stateFlex
+	ls.flexDays++
 	return true, summary.Summary{}
 }
 
 func (ls *LogSummary) summarize() summary.Summary {
 	res := summary.Summary{
-		Valid: true,
+		Valid:  true,
+		Target: ls.FullDay,
 	}
 
 	sumDurations := time.Duration(0)
@@ -255,7 +460,20 @@ func (ls *LogSummary) summarize() summary.Summary {
 	for _, d := range ls.durations {
 		sumDurations = sumDurations + d
 	}
+
+	flexDayTime := time.Duration(ls.flexDays) * ls.FullDay
+	sumDurations += flexDayTime
+
+	warnings, deduction := ls.checkPolicy()
+	sumDurations -= deduction
+	res.Warnings = append(res.Warnings, warnings...)
 	res.TimeWorked = sumDurations
+	res.FlexUsed = ls.flexUsed + flexDayTime
+	res.FlexEarned = ls.flexEarned
+	res.BreakTime = ls.breakTime
+	for _, paid := range ls.paidBreakTime {
+		res.PaidBreakTime += paid
+	}
 
 	if sumDurations < ls.FullDay {
 		timeLeft := ls.FullDay - sumDurations
@@ -276,6 +494,8 @@ func (ls *LogSummary) summarize() summary.Summary {
 		res.AddCategory(cat, dur)
 	}
 
+	ls.applyRates(&res)
+
 	if ls.currentDate.Day != 0 && ls.currentDate.Month != 0 {
 		res.Date = &summary.Date{
 			DayName: ls.currentDate.DayName,
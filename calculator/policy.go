@@ -0,0 +1,225 @@
+package calculator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sporadisk/clocker/config"
+	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/locale"
+)
+
+// WorkPolicy describes the break rules that each day's sessions are checked
+// against. Violations are reported as summary warnings.
+type WorkPolicy struct {
+	BreakRules     []BreakRule
+	MaxContinuous  time.Duration // the longest stretch of work without a break, 0 for no limit
+	MinBreakLength time.Duration // gaps between sessions shorter than this are not breaks
+	AutoLunch      *AutoLunch
+}
+
+// BreakRule requires at least MinBreak of breaks on days with more than After
+// of work.
+type BreakRule struct {
+	After    time.Duration
+	MinBreak time.Duration
+}
+
+// AutoLunch deducts a lunch break from days with more than After of work, if
+// less than Duration of breaks was logged.
+type AutoLunch struct {
+	After    time.Duration
+	Duration time.Duration
+}
+
+// LoadWorkPolicy reads the work policy from the calculator config.
+func LoadWorkPolicy(conf *config.CalcConfig) (WorkPolicy, error) {
+	policy := WorkPolicy{}
+	if conf == nil {
+		return policy, nil
+	}
+
+	var err error
+	for i, rule := range conf.BreakRules {
+		br := BreakRule{}
+		br.After, err = format.ParseDuration(rule.After)
+		if err != nil {
+			return policy, fmt.Errorf("breakRules[%d]: invalid after duration: %w", i, err)
+		}
+		br.MinBreak, err = format.ParseDuration(rule.MinBreak)
+		if err != nil {
+			return policy, fmt.Errorf("breakRules[%d]: invalid minBreak duration: %w", i, err)
+		}
+		policy.BreakRules = append(policy.BreakRules, br)
+	}
+
+	if conf.MaxContinuousWork != "" {
+		policy.MaxContinuous, err = format.ParseDuration(conf.MaxContinuousWork)
+		if err != nil {
+			return policy, fmt.Errorf("invalid maxContinuousWork duration: %w", err)
+		}
+	}
+
+	if conf.MinBreakLength != "" {
+		policy.MinBreakLength, err = format.ParseDuration(conf.MinBreakLength)
+		if err != nil {
+			return policy, fmt.Errorf("invalid minBreakLength duration: %w", err)
+		}
+	}
+
+	if conf.AutoLunch != nil {
+		lunch := &AutoLunch{}
+		lunch.After, err = format.ParseDuration(conf.AutoLunch.After)
+		if err != nil {
+			return policy, fmt.Errorf("autoLunch: invalid after duration: %w", err)
+		}
+		lunch.Duration, err = format.ParseDuration(conf.AutoLunch.Duration)
+		if err != nil {
+			return policy, fmt.Errorf("autoLunch: invalid duration: %w", err)
+		}
+		policy.AutoLunch = lunch
+	}
+
+	return policy, nil
+}
+
+// checkPolicy checks the recorded sessions of each day against the work
+// policy. It returns the warnings, and the time to deduct from the time
+// worked for lunches that weren't logged. The deductions are also trimmed from
+// the events and category totals.
+func (ls *LogSummary) checkPolicy() (warnings []string, deduction time.Duration) {
+	days := map[string][]session{}
+	var dates []string
+	for _, s := range ls.sessions {
+		if !s.working {
+			continue
+		}
+
+		date := s.start.Format("2006-01-02")
+		if _, ok := days[date]; !ok {
+			dates = append(dates, date)
+		}
+		days[date] = append(days[date], s)
+	}
+	sort.Strings(dates)
+
+	for _, date := range dates {
+		dayWarnings, dayDeduction := ls.Policy.checkDay(days[date], ls.Locale)
+		warnings = append(warnings, dayWarnings...)
+		deduction += dayDeduction
+		if dayDeduction > 0 {
+			ls.deductLunch(days[date], dayDeduction)
+		}
+	}
+
+	return warnings, deduction
+}
+
+// deductLunch trims the deducted lunch from the end of the session in which
+// the day's work passes AutoLunch.After, continuing with the following and
+// then the preceding sessions if it's too short. The sessions are sorted by
+// start time.
+func (ls *LogSummary) deductLunch(sessions []session, deduction time.Duration) {
+	first := len(sessions) - 1
+	worked := time.Duration(0)
+	for i, s := range sessions {
+		worked += s.end.Sub(s.start)
+		if worked > ls.Policy.AutoLunch.After {
+			first = i
+			break
+		}
+	}
+
+	order := append(append([]session{}, sessions[first:]...), sessions[:first]...)
+	for _, s := range order {
+		if deduction <= 0 {
+			break
+		}
+
+		trim := min(deduction, s.event.End.Sub(s.event.Start))
+		s.event.End = s.event.End.Add(-trim)
+		s.event.DetermineHours()
+		ls.addToCategory(s.category, -trim)
+		deduction -= trim
+	}
+
+	// drop the events that were trimmed away entirely
+	kept := ls.events[:0]
+	for _, e := range ls.events {
+		if e.Start.IsZero() || e.End.After(e.Start) {
+			kept = append(kept, e)
+		}
+	}
+	ls.events = kept
+}
+
+func (wp *WorkPolicy) checkDay(sessions []session, loc *locale.Locale) (warnings []string, deduction time.Duration) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].start.Before(sessions[j].start)
+	})
+
+	worked := time.Duration(0)
+	breaks := time.Duration(0)
+	stretchStart := sessions[0].start
+	stretchWorked := time.Duration(0)
+
+	for i, s := range sessions {
+		worked += s.end.Sub(s.start)
+
+		if i > 0 {
+			gap := s.start.Sub(sessions[i-1].end)
+			if gap > 0 && gap >= wp.MinBreakLength {
+				breaks += gap
+				warnings = append(warnings, wp.checkStretch(stretchStart, sessions[i-1].end, stretchWorked, loc)...)
+				stretchStart = s.start
+				stretchWorked = 0
+			}
+		}
+		stretchWorked += s.end.Sub(s.start)
+	}
+	warnings = append(warnings, wp.checkStretch(stretchStart, sessions[len(sessions)-1].end, stretchWorked, loc)...)
+
+	if wp.AutoLunch != nil && worked > wp.AutoLunch.After && breaks < wp.AutoLunch.Duration {
+		deduction = wp.AutoLunch.Duration - breaks
+		breaks += deduction
+		warnings = append(warnings, loc.Sprintf("Less than %s of breaks logged after %s of work: Deducted %s for lunch",
+			format.DurationHM(wp.AutoLunch.Duration),
+			format.DurationHM(wp.AutoLunch.After),
+			format.DurationHM(deduction),
+		))
+	}
+
+	for _, rule := range wp.BreakRules {
+		if worked > rule.After && breaks < rule.MinBreak {
+			warnings = append(warnings, loc.Sprintf("Worked %s with %s of breaks: At least %s of breaks is required after %s of work",
+				format.DurationHM(worked),
+				breakDuration(breaks, loc),
+				format.DurationHM(rule.MinBreak),
+				format.DurationHM(rule.After),
+			))
+		}
+	}
+
+	return warnings, deduction
+}
+
+func (wp *WorkPolicy) checkStretch(start, end time.Time, worked time.Duration, loc *locale.Locale) []string {
+	if wp.MaxContinuous == 0 || worked <= wp.MaxContinuous {
+		return nil
+	}
+
+	return []string{loc.Sprintf("Worked %s without a break from %s to %s: The maximum is %s",
+		format.DurationHM(worked),
+		format.Timestamp(start),
+		format.Timestamp(end),
+		format.DurationHM(wp.MaxContinuous),
+	)}
+}
+
+func breakDuration(d time.Duration, loc *locale.Locale) string {
+	if d == 0 {
+		return loc.Sprintf("no time")
+	}
+	return format.DurationHM(d)
+}
@@ -0,0 +1,256 @@
+package calculator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/config"
+	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/summary"
+)
+
+const (
+	MergeAdjacent = "adjacent" // merge events with the same task that follow each other without a gap
+	MergeTask     = "task"     // merge all events with the same task on a day
+)
+
+// EventProcessing describes the changes made to the summarized events before
+// they are reported and exported.
+type EventProcessing struct {
+	Merge       string
+	MinDuration time.Duration   // events shorter than this are dropped, after merging
+	SplitAt     []time.Duration // times of day where events are split, e.g. midnight
+}
+
+// LoadEventProcessing reads the event processing rules from the calculator
+// config. It returns nil if no rules have been configured.
+func LoadEventProcessing(conf *config.CalcConfig) (*EventProcessing, error) {
+	if conf == nil || conf.Events == nil {
+		return nil, nil
+	}
+
+	p := &EventProcessing{
+		Merge: strings.ToLower(conf.Events.Merge),
+	}
+
+	switch p.Merge {
+	case "", MergeAdjacent, MergeTask:
+	default:
+		return nil, fmt.Errorf("unknown merge mode: %q", conf.Events.Merge)
+	}
+
+	var err error
+	if conf.Events.MinDuration != "" {
+		p.MinDuration, err = format.ParseDuration(conf.Events.MinDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minDuration: %w", err)
+		}
+	}
+
+	for _, s := range conf.Events.SplitAt {
+		at, err := parseTimeOfDay(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid splitAt time %q: %w", s, err)
+		}
+		p.SplitAt = append(p.SplitAt, at%(24*time.Hour))
+	}
+
+	return p, nil
+}
+
+// Process merges, splits and filters the events. The events passed in are
+// left unchanged.
+func (p *EventProcessing) Process(events []*event.Event) []*event.Event {
+	result, _ := p.process(events)
+	return result
+}
+
+// ProcessSummary processes the events, and takes the time of the dropped
+// events out of the summary's category totals, so that they match the events.
+// The time worked is left as it is.
+func (p *EventProcessing) ProcessSummary(sum *summary.Summary, events []*event.Event) []*event.Event {
+	result, dropped := p.process(events)
+	for _, e := range dropped {
+		subtractCategory(sum, e.Category, eventDuration(e))
+	}
+	return result
+}
+
+func (p *EventProcessing) process(events []*event.Event) (result, dropped []*event.Event) {
+	result = make([]*event.Event, 0, len(events))
+	for _, e := range events {
+		c := *e
+		result = append(result, &c)
+	}
+
+	switch p.Merge {
+	case MergeAdjacent:
+		result = mergeEvents(result, true)
+	case MergeTask:
+		result = mergeEvents(result, false)
+	}
+
+	if len(p.SplitAt) > 0 {
+		var split []*event.Event
+		for _, e := range result {
+			split = append(split, p.split(e)...)
+		}
+		result = split
+	}
+
+	if p.MinDuration > 0 {
+		var kept []*event.Event
+		for _, e := range result {
+			if e.Duration() >= p.MinDuration {
+				kept = append(kept, e)
+			} else {
+				dropped = append(dropped, e)
+			}
+		}
+		result = kept
+	}
+
+	return result, dropped
+}
+
+// eventDuration returns the exact duration of timed events, which Duration
+// rounds down to whole minutes.
+func eventDuration(e *event.Event) time.Duration {
+	if e.Start.IsZero() || e.End.IsZero() {
+		return e.Duration()
+	}
+	return e.End.Sub(e.Start)
+}
+
+func subtractCategory(sum *summary.Summary, name string, d time.Duration) {
+	for i, c := range sum.Categories {
+		if !c.MatchName(name) {
+			continue
+		}
+
+		sum.Categories[i].TimeWorked -= d
+		if sum.Categories[i].TimeWorked <= 0 {
+			sum.Categories = append(sum.Categories[:i], sum.Categories[i+1:]...)
+		}
+		return
+	}
+}
+
+func sameTask(a, b *event.Event) bool {
+	return a.Date == b.Date &&
+		strings.EqualFold(a.Category, b.Category) &&
+		strings.EqualFold(a.Task, b.Task)
+}
+
+// mergeEvents merges events with the same task. Events merged across a gap
+// lose their start and end times, and are kept as a duration, since the merged
+// event would otherwise overlap the events in the gap. Their timed segments
+// are kept as the parts of the event, for the exporters that need times.
+func mergeEvents(events []*event.Event, adjacentOnly bool) []*event.Event {
+	var merged []*event.Event
+	for _, e := range events {
+		var target *event.Event
+		if adjacentOnly {
+			if len(merged) > 0 {
+				last := merged[len(merged)-1]
+				if sameTask(last, e) && !last.End.IsZero() && last.End.Equal(e.Start) {
+					target = last
+				}
+			}
+		} else {
+			for _, m := range merged {
+				if sameTask(m, e) {
+					target = m
+					break
+				}
+			}
+		}
+
+		if target == nil {
+			merged = append(merged, e)
+			continue
+		}
+
+		if e.Note != "" && !strings.Contains(target.Note, e.Note) {
+			if target.Note != "" {
+				target.Note += "; "
+			}
+			target.Note += e.Note
+		}
+
+		adjacent := !target.End.IsZero() && target.End.Equal(e.Start)
+		total := eventDuration(target) + eventDuration(e)
+		if adjacent {
+			target.End = e.End
+			target.DetermineHours()
+		} else {
+			target.Parts = append(timedParts(target), timedParts(e)...)
+			target.Start = time.Time{}
+			target.End = time.Time{}
+			target.SetDuration(total)
+		}
+	}
+
+	return merged
+}
+
+// timedParts returns the timed segments of an event, which is either the
+// event itself, or the parts it was merged from.
+func timedParts(e *event.Event) []*event.Event {
+	if e.Start.IsZero() || e.End.IsZero() {
+		return e.Parts
+	}
+
+	part := *e
+	part.Parts = nil
+	return []*event.Event{&part}
+}
+
+// split splits the event at the configured times of day.
+func (p *EventProcessing) split(e *event.Event) []*event.Event {
+	if e.Start.IsZero() || e.End.IsZero() {
+		return []*event.Event{e}
+	}
+
+	var points []time.Time
+	for day := truncateDay(e.Start); day.Before(e.End); day = day.AddDate(0, 0, 1) {
+		for _, at := range p.SplitAt {
+			point := day.Add(at)
+			if point.After(e.Start) && point.Before(e.End) {
+				points = append(points, point)
+			}
+		}
+	}
+
+	if len(points) == 0 {
+		return []*event.Event{e}
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Before(points[j])
+	})
+	points = append(points, e.End)
+
+	var parts []*event.Event
+	start := e.Start
+	for _, end := range points {
+		part := *e
+		part.Start = start
+		part.End = end
+		part.DetermineHours()
+		if e.Date.Year != 0 {
+			part.Date = event.EventDate{Day: start.Day(), Month: int(start.Month()), Year: start.Year()}
+		}
+		parts = append(parts, &part)
+		start = end
+	}
+
+	return parts
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,307 @@
+package calculator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/config"
+	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/summary"
+)
+
+// RateWindow is a part of the week where work is compensated at a different
+// rate, such as evenings, weekends or on-call standby.
+type RateWindow struct {
+	Name       string
+	Days       []time.Weekday // the days the window starts on, empty for every day
+	From       time.Duration  // time of day
+	To         time.Duration  // time of day, before From if the window spans midnight
+	Category   string         // only applies to this category, empty for every category
+	Multiplier float64
+}
+
+// RateTable is the set of rate windows that events are checked against. Time
+// that isn't covered by any window is compensated at the normal rate. Where
+// windows overlap, a window for the event's category is preferred, and then
+// the one with the highest multiplier.
+type RateTable struct {
+	Windows []RateWindow
+}
+
+// LoadRateTable reads the rate windows from the calculator config.
+func LoadRateTable(conf *config.CalcConfig) (RateTable, error) {
+	table := RateTable{}
+	if conf == nil {
+		return table, nil
+	}
+
+	for i, rc := range conf.Rates {
+		w := RateWindow{
+			Name:       rc.Name,
+			Category:   format.CleanParam(rc.Category),
+			Multiplier: rc.Multiplier,
+		}
+
+		if w.Name == "" {
+			return table, fmt.Errorf("rates[%d]: missing name", i)
+		}
+
+		if strings.EqualFold(w.Name, summary.NormalRate) {
+			return table, fmt.Errorf("rates[%d]: the name %q is reserved", i, w.Name)
+		}
+
+		for _, prev := range table.Windows {
+			if strings.EqualFold(prev.Name, w.Name) {
+				return table, fmt.Errorf("rates[%d]: the name %q is already in use", i, w.Name)
+			}
+		}
+
+		if w.Multiplier < 0 {
+			return table, fmt.Errorf("rates[%d]: the multiplier can not be negative", i)
+		}
+
+		for _, d := range rc.Days {
+			days, err := parseWeekdays(d)
+			if err != nil {
+				return table, fmt.Errorf("rates[%d]: %w", i, err)
+			}
+			w.Days = append(w.Days, days...)
+		}
+
+		var err error
+		w.From, err = parseTimeOfDay(rc.From)
+		if err != nil {
+			return table, fmt.Errorf("rates[%d]: invalid from time: %w", i, err)
+		}
+
+		w.To, err = parseTimeOfDay(rc.To)
+		if err != nil {
+			return table, fmt.Errorf("rates[%d]: invalid to time: %w", i, err)
+		}
+
+		table.Windows = append(table.Windows, w)
+	}
+
+	return table, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	t, err := format.ParseTimestamp(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)), nil
+}
+
+func parseWeekdays(s string) ([]time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "weekdays":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, nil
+	case "weekend":
+		return []time.Weekday{time.Saturday, time.Sunday}, nil
+	}
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || (len(s) >= 3 && strings.HasPrefix(name, s)) {
+			return []time.Weekday{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown weekday %q", s)
+}
+
+// span returns the window's occurrence starting on the day. Windows without
+// from and to times cover the whole day.
+func (w *RateWindow) span(day time.Time) (start, end time.Time) {
+	start = day.Add(w.From)
+	end = day.Add(w.To)
+	if !end.After(start) {
+		end = end.Add(24 * time.Hour)
+	}
+	return start, end
+}
+
+func (w *RateWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *RateWindow) allDay() bool {
+	return len(w.Days) == 0 && w.From == 0 && w.To == 0
+}
+
+// bestWindow picks the window to use among the ones that apply.
+func bestWindow(windows []*RateWindow) *RateWindow {
+	var best *RateWindow
+	for _, w := range windows {
+		switch {
+		case best == nil:
+			best = w
+		case (w.Category != "") != (best.Category != ""):
+			if w.Category != "" {
+				best = w
+			}
+		case w.Multiplier > best.Multiplier:
+			best = w
+		}
+	}
+	return best
+}
+
+// classify splits the event's time between the rate windows. Time outside of
+// every window is returned under summary.NormalRate. The weekday restrictions
+// only apply to dated events.
+func (rt *RateTable) classify(e *event.Event, dated bool) map[string]time.Duration {
+	var windows []*RateWindow
+	for i, w := range rt.Windows {
+		if w.Category == "" || strings.EqualFold(w.Category, e.Category) {
+			windows = append(windows, &rt.Windows[i])
+		}
+	}
+
+	result := map[string]time.Duration{}
+
+	// events without timestamps can only be placed in windows that cover the
+	// whole week
+	if e.Start.IsZero() || e.End.IsZero() {
+		var applicable []*RateWindow
+		for _, w := range windows {
+			if w.allDay() {
+				applicable = append(applicable, w)
+			}
+		}
+
+		name := summary.NormalRate
+		if best := bestWindow(applicable); best != nil {
+			name = best.Name
+		}
+		result[name] += e.Duration()
+		return result
+	}
+
+	type occurrence struct {
+		window     *RateWindow
+		start, end time.Time
+	}
+
+	// collect the window occurrences that overlap the event, starting the day
+	// before, in case a window spans midnight
+	var occurrences []occurrence
+	boundaries := []time.Time{e.Start, e.End}
+	firstDay := time.Date(e.Start.Year(), e.Start.Month(), e.Start.Day()-1, 0, 0, 0, 0, e.Start.Location())
+	for day := firstDay; day.Before(e.End); day = day.AddDate(0, 0, 1) {
+		for _, w := range windows {
+			if dated && !w.onDay(day.Weekday()) {
+				continue
+			}
+
+			if !dated && len(w.Days) > 0 {
+				continue
+			}
+
+			start, end := w.span(day)
+			if !start.Before(e.End) || !end.After(e.Start) {
+				continue
+			}
+
+			occurrences = append(occurrences, occurrence{w, start, end})
+			boundaries = append(boundaries, start, end)
+		}
+	}
+
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	for i := 0; i < len(boundaries)-1; i++ {
+		from, to := boundaries[i], boundaries[i+1]
+		if from.Before(e.Start) || to.After(e.End) || !to.After(from) {
+			continue
+		}
+
+		var applicable []*RateWindow
+		for _, o := range occurrences {
+			if !from.Before(o.start) && from.Before(o.end) {
+				applicable = append(applicable, o.window)
+			}
+		}
+
+		name := summary.NormalRate
+		if best := bestWindow(applicable); best != nil {
+			name = best.Name
+		}
+		result[name] += to.Sub(from)
+	}
+
+	return result
+}
+
+// applyRates breaks the time worked down by rate class, and adds the weighted
+// time to the summary. Time that isn't logged as events, such as flex days and
+// paid breaks, is counted at the normal rate.
+func (ls *LogSummary) applyRates(res *summary.Summary) {
+	if len(ls.Rates.Windows) == 0 {
+		return
+	}
+
+	dated := ls.currentDate.Day != 0 && ls.currentDate.Month != 0
+	classes := map[string]time.Duration{}
+	for _, e := range ls.events {
+		if ls.Breaks.IsBreak(e.Category) {
+			continue
+		}
+
+		for name, d := range ls.Rates.classify(e, dated) {
+			classes[name] += d
+		}
+	}
+
+	res.WeightedTime = res.TimeWorked
+	for _, w := range ls.Rates.Windows {
+		d, ok := classes[w.Name]
+		if !ok {
+			continue
+		}
+		delete(classes, w.Name)
+
+		res.RateClasses = append(res.RateClasses, summary.RateClass{
+			Name:       w.Name,
+			Multiplier: w.Multiplier,
+			TimeWorked: d,
+		})
+		res.WeightedTime += time.Duration(float64(d) * (w.Multiplier - 1))
+	}
+
+	normal := res.TimeWorked
+	for _, rc := range res.RateClasses {
+		normal -= rc.TimeWorked
+	}
+
+	if normal > 0 {
+		res.RateClasses = append([]summary.RateClass{{
+			Name:       summary.NormalRate,
+			Multiplier: 1,
+			TimeWorked: normal,
+		}}, res.RateClasses...)
+	}
+
+	res.WeightedTime = res.WeightedTime.Round(time.Minute)
+}
@@ -3,8 +3,10 @@ package calculator
 import (
 	"fmt"
 
+	"github.com/sporadisk/clocker/client/report"
 	"github.com/sporadisk/clocker/client/terminal"
 	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/locale"
 )
 
 func (c *Calculator) LoadSummaryOutput() error {
@@ -12,6 +14,23 @@ func (c *Calculator) LoadSummaryOutput() error {
 	return c.LoadTerminalOutput()
 }
 
+// LoadLocale loads the output locale, which is used for the terminal output,
+// reports, export prompts and warnings.
+func (c *Calculator) LoadLocale() error {
+	localeName := ""
+	if c.Conf.Output != nil && c.Conf.Output.Params != nil {
+		localeName = c.Conf.Output.Params["locale"]
+	}
+
+	loc, err := locale.Load(localeName)
+	if err != nil {
+		return fmt.Errorf("locale.Load: %w", err)
+	}
+
+	c.Locale = loc
+	return nil
+}
+
 func (c *Calculator) LoadTerminalOutput() error {
 	defaultTimeFormat := format.TimeHM
 	if c.Conf.Output != nil && c.Conf.Output.Params != nil {
@@ -24,6 +43,7 @@ func (c *Calculator) LoadTerminalOutput() error {
 	// currently only one output type: Terminal
 	termClient := &terminal.Client{
 		TimeFormat: defaultTimeFormat,
+		Locale:     c.Locale,
 	}
 	err := termClient.Init()
 	if err != nil {
@@ -33,3 +53,32 @@ func (c *Calculator) LoadTerminalOutput() error {
 	c.SummaryOutput = termClient
 	return nil
 }
+
+// LoadReportWriter sets up report file generation, if it has been configured.
+func (c *Calculator) LoadReportWriter() error {
+	if c.Conf.Report == nil {
+		return nil
+	}
+
+	timeFormat := format.TimeHM
+	if c.Conf.Output != nil && c.Conf.Output.Params != nil {
+		tf, ok := c.Conf.Output.Params["timeFormat"]
+		if ok {
+			timeFormat = tf
+		}
+	}
+
+	writer := &report.Writer{
+		Dir:        c.Conf.Report.Dir,
+		Format:     c.Conf.Report.Format,
+		TimeFormat: timeFormat,
+		Locale:     c.Locale,
+	}
+	err := writer.Init()
+	if err != nil {
+		return fmt.Errorf("report.Writer.Init: %w", err)
+	}
+
+	c.ReportWriter = writer
+	return nil
+}
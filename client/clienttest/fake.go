@@ -0,0 +1,88 @@
+// Package clienttest has a stand-in for the JSON APIs that the exporters talk
+// to, for use in their tests.
+package clienttest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// FakeAPI is a stand-in for a JSON API. Requests that Authorized refuses are
+// answered with 401, GET requests are answered by Get, and the bodies of POST
+// requests are recorded by path.
+type FakeAPI struct {
+	T          *testing.T
+	Authorized func(r *http.Request) bool
+	Get        func(r *http.Request) any // the response, or nil for unknown endpoints
+	Fail       map[string]bool           // paths where POST requests fail
+
+	posted map[string][]json.RawMessage
+}
+
+// NewServer starts a server for the fake, which is closed when the test ends.
+func (f *FakeAPI) NewServer() *httptest.Server {
+	server := httptest.NewServer(f)
+	f.T.Cleanup(server.Close)
+	return server
+}
+
+func (f *FakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.Authorized != nil && !f.Authorized(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		var resp any
+		if f.Get != nil {
+			resp = f.Get(r)
+		}
+		if resp == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(resp)
+	case http.MethodPost:
+		if f.Fail[r.URL.Path] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if !json.Valid(body) {
+			f.T.Errorf("POST %s: invalid JSON: %s", r.URL.Path, body)
+		}
+		if f.posted == nil {
+			f.posted = map[string][]json.RawMessage{}
+		}
+		f.posted[r.URL.Path] = append(f.posted[r.URL.Path], body)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// Paths returns the number of paths that have been posted to.
+func (f *FakeAPI) Paths() int {
+	return len(f.posted)
+}
+
+// Posted decodes the bodies that have been posted to the path.
+func Posted[T any](f *FakeAPI, path string) []T {
+	var posted []T
+	for _, body := range f.posted[path] {
+		var v T
+		err := json.Unmarshal(body, &v)
+		if err != nil {
+			f.T.Errorf("json.Unmarshal: %s", err)
+		}
+		posted = append(posted, v)
+	}
+	return posted
+}
@@ -0,0 +1,145 @@
+package harvest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/client"
+)
+
+// Client exports events to Harvest, through the v2 API.
+type Client struct {
+	// Configuration
+	ApiURL         string
+	AccountID      string
+	ApiToken       string
+	Projects       map[string]int // project ID, by category
+	Tasks          map[string]int // task ID, by category
+	DefaultProject int
+	DefaultTask    int
+
+	// State
+	HttpClient  *client.HttpClient
+	api         *client.JSONClient
+	userID      int
+	assignments []projectAssignment
+}
+
+type user struct {
+	ID int `json:"id"`
+}
+
+type ref struct {
+	ID   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type projectAssignment struct {
+	Project         ref              `json:"project"`
+	TaskAssignments []taskAssignment `json:"task_assignments"`
+}
+
+type taskAssignment struct {
+	Task ref `json:"task"`
+}
+
+type page struct {
+	NextPage *int `json:"next_page"`
+}
+
+func (c *Client) Init(ctx context.Context) error {
+	if c.HttpClient == nil {
+		c.HttpClient = client.NewHttpClient(10 * time.Second)
+	}
+	if c.ApiURL == "" {
+		c.ApiURL = "https://api.harvestapp.com/"
+	}
+
+	apiURL, err := url.Parse(c.ApiURL)
+	if err != nil {
+		return fmt.Errorf("invalid ApiURL: %w", err)
+	}
+	c.api = &client.JSONClient{
+		Http:    c.HttpClient,
+		BaseURL: apiURL.JoinPath("v2"),
+		Authorize: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+c.ApiToken)
+			req.Header.Set("Harvest-Account-Id", c.AccountID)
+			req.Header.Set("User-Agent", "clocker")
+		},
+	}
+
+	var me user
+	err = c.api.GetJSON("users/me", nil, &me)
+	if err != nil {
+		return fmt.Errorf("getJSON(users/me): %w", err)
+	}
+	c.userID = me.ID
+
+	err = c.getAssignments()
+	if err != nil {
+		return fmt.Errorf("getAssignments: %w", err)
+	}
+
+	if len(c.Projects) == 0 && c.DefaultProject == 0 {
+		fmt.Println("\nNo Harvest projects have been mapped; use the IDs from the following list:")
+		fmt.Print(c.ListAssignments())
+		return fmt.Errorf("no project.<category> or defaultProject params")
+	}
+
+	return nil
+}
+
+func (c *Client) getAssignments() error {
+	c.assignments = nil
+	params := url.Values{}
+	for pageNum := 1; ; pageNum++ {
+		params.Set("page", strconv.Itoa(pageNum))
+
+		var resp struct {
+			page
+			ProjectAssignments []projectAssignment `json:"project_assignments"`
+		}
+		err := c.api.GetJSON("users/me/project_assignments", params, &resp)
+		if err != nil {
+			return fmt.Errorf("getJSON(project_assignments): %w", err)
+		}
+
+		c.assignments = append(c.assignments, resp.ProjectAssignments...)
+		if resp.NextPage == nil {
+			return nil
+		}
+	}
+}
+
+// ListAssignments lists the projects and tasks that the user can log time to.
+func (c *Client) ListAssignments() string {
+	var sb strings.Builder
+	for _, pa := range c.assignments {
+		fmt.Fprintf(&sb, " - Project ID: %d, Name: %s\n", pa.Project.ID, pa.Project.Name)
+		for _, ta := range pa.TaskAssignments {
+			fmt.Fprintf(&sb, "   - Task ID: %d, Name: %s\n", ta.Task.ID, ta.Task.Name)
+		}
+	}
+	return sb.String()
+}
+
+// assigned checks if the user can log time to the task in the project.
+func (c *Client) assigned(projectID, taskID int) bool {
+	for _, pa := range c.assignments {
+		if pa.Project.ID != projectID {
+			continue
+		}
+		for _, ta := range pa.TaskAssignments {
+			if ta.Task.ID == taskID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package harvest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+type timeEntry struct {
+	ID        int     `json:"id,omitempty"`
+	ProjectID int     `json:"project_id"`
+	TaskID    int     `json:"task_id"`
+	SpentDate string  `json:"spent_date"` // format: YYYY-MM-DD
+	Hours     float64 `json:"hours"`
+	Notes     string  `json:"notes,omitempty"`
+}
+
+// existingEntry is the shape of time entries returned by Harvest.
+type existingEntry struct {
+	ID        int     `json:"id"`
+	SpentDate string  `json:"spent_date"`
+	Hours     float64 `json:"hours"`
+	Notes     string  `json:"notes"`
+	Project   ref     `json:"project"`
+	Task      ref     `json:"task"`
+}
+
+// Export creates a time entry for each event. Entries that already exist with
+// the same date, project, task, hours and notes are skipped, so that exporting
+// a day again only adds what's missing.
+func (c *Client) Export(events []*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	entries := make([]*timeEntry, 0, len(events))
+	for _, e := range events {
+		te, err := c.eventToTimeEntry(e)
+		if err != nil {
+			return fmt.Errorf("eventToTimeEntry: %w", err)
+		}
+		entries = append(entries, te)
+	}
+
+	existing, err := c.existingEntries(entries)
+	if err != nil {
+		return fmt.Errorf("existingEntries: %w", err)
+	}
+
+	created, skipped := 0, 0
+	for _, te := range entries {
+		key := entryKey(te.SpentDate, te.ProjectID, te.TaskID, te.Hours, te.Notes)
+		if existing[key] > 0 {
+			// each existing entry only accounts for one event
+			existing[key]--
+			skipped++
+			continue
+		}
+
+		body, err := json.Marshal(te)
+		if err != nil {
+			return fmt.Errorf("json.Marshal: %w", err)
+		}
+
+		_, err = c.api.Request(http.MethodPost, "time_entries", nil, body)
+		if err != nil {
+			return fmt.Errorf("creating time entry %q: %w", te.Notes, err)
+		}
+		created++
+	}
+
+	fmt.Printf("Created %d Harvest time entries, skipped %d that already existed.\n", created, skipped)
+	return nil
+}
+
+func (c *Client) eventToTimeEntry(e *event.Event) (*timeEntry, error) {
+	category := strings.ToLower(e.Category)
+	te := &timeEntry{
+		ProjectID: c.DefaultProject,
+		TaskID:    c.DefaultTask,
+		SpentDate: fmt.Sprintf("%04d-%02d-%02d", e.Date.Year, e.Date.Month, e.Date.Day),
+		Hours:     hours(e),
+		Notes:     e.Description(),
+	}
+
+	if id, ok := c.Projects[category]; ok {
+		te.ProjectID = id
+	}
+	if id, ok := c.Tasks[category]; ok {
+		te.TaskID = id
+	}
+
+	if te.ProjectID == 0 || te.TaskID == 0 {
+		return nil, fmt.Errorf("no Harvest project and task for the category %q - Available assignments:\n%s", e.Category, c.ListAssignments())
+	}
+
+	if !c.assigned(te.ProjectID, te.TaskID) {
+		return nil, fmt.Errorf("task %d in project %d is not assigned to the user - Available assignments:\n%s", te.TaskID, te.ProjectID, c.ListAssignments())
+	}
+
+	return te, nil
+}
+
+// existingEntries counts the user's entries on the dates of the entries, by
+// their key.
+func (c *Client) existingEntries(entries []*timeEntry) (map[string]int, error) {
+	from, to := entries[0].SpentDate, entries[0].SpentDate
+	for _, te := range entries {
+		from = min(from, te.SpentDate)
+		to = max(to, te.SpentDate)
+	}
+
+	params := url.Values{}
+	params.Set("user_id", strconv.Itoa(c.userID))
+	params.Set("from", from)
+	params.Set("to", to)
+
+	counts := map[string]int{}
+	for pageNum := 1; ; pageNum++ {
+		params.Set("page", strconv.Itoa(pageNum))
+
+		var resp struct {
+			page
+			TimeEntries []existingEntry `json:"time_entries"`
+		}
+		err := c.api.GetJSON("time_entries", params, &resp)
+		if err != nil {
+			return nil, fmt.Errorf("getJSON(time_entries): %w", err)
+		}
+
+		for _, ee := range resp.TimeEntries {
+			counts[entryKey(ee.SpentDate, ee.Project.ID, ee.Task.ID, ee.Hours, ee.Notes)]++
+		}
+
+		if resp.NextPage == nil {
+			return counts, nil
+		}
+	}
+}
+
+func entryKey(date string, projectID, taskID int, hours float64, notes string) string {
+	return fmt.Sprintf("%s/%d/%d/%.2f/%s", date, projectID, taskID, hours, notes)
+}
+
+// hours returns the duration of the event in hours, rounded to two decimals.
+func hours(e *event.Event) float64 {
+	return math.Round(e.Duration().Hours()*100) / 100
+}
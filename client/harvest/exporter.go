@@ -0,0 +1,75 @@
+package harvest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "harvest",
+		Description: "Harvest, authorized with a personal access token",
+		Required:    []string{"accountId", "apiToken"},
+		Optional:    []string{"apiUrl", "defaultProject", "defaultTask", "project.<category>", "task.<category>"},
+		Factory:     NewExporter,
+	})
+}
+
+// NewExporter creates and initializes a Harvest client from exporter params.
+// Categories are mapped to project and task IDs with "project.<category>" and
+// "task.<category>" params.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "accountId", "apiToken")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		ApiURL:    params["apiUrl"],
+		AccountID: p["accountId"],
+		ApiToken:  p["apiToken"],
+	}
+
+	client.Projects, err = idParams(params, "project.")
+	if err != nil {
+		return nil, err
+	}
+
+	client.Tasks, err = idParams(params, "task.")
+	if err != nil {
+		return nil, err
+	}
+
+	for key, dst := range map[string]*int{"defaultProject": &client.DefaultProject, "defaultTask": &client.DefaultTask} {
+		value, ok := params[key]
+		if !ok {
+			continue
+		}
+		*dst, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s as int: %w", key, err)
+		}
+	}
+
+	err = client.Init(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("harvest.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
+
+func idParams(params map[string]string, prefix string) (map[string]int, error) {
+	ids := map[string]int{}
+	for cat, value := range event.PrefixedParams(params, prefix) {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse %s%s as int: %w", prefix, cat, err)
+		}
+		ids[cat] = id
+	}
+	return ids, nil
+}
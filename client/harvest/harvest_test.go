@@ -0,0 +1,102 @@
+package harvest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/sporadisk/clocker/client/clienttest"
+	"github.com/sporadisk/clocker/event"
+)
+
+// newFakeHarvest is a stand-in for the parts of the Harvest API used by the
+// client, with the existing time entries of the user.
+func newFakeHarvest(t *testing.T, existing []existingEntry) *clienttest.FakeAPI {
+	return &clienttest.FakeAPI{
+		T: t,
+		Authorized: func(r *http.Request) bool {
+			return r.Header.Get("Authorization") == "Bearer secret-token" && r.Header.Get("Harvest-Account-Id") == "123"
+		},
+		Get: func(r *http.Request) any {
+			switch r.URL.Path {
+			case "/v2/users/me":
+				return user{ID: 5}
+			case "/v2/users/me/project_assignments":
+				// two pages, to exercise the pagination
+				if r.URL.Query().Get("page") == "1" {
+					return map[string]any{
+						"next_page":           2,
+						"project_assignments": []projectAssignment{{Project: ref{ID: 10, Name: "Acme"}, TaskAssignments: []taskAssignment{{Task: ref{ID: 100, Name: "Development"}}}}},
+					}
+				}
+				return map[string]any{
+					"next_page":           nil,
+					"project_assignments": []projectAssignment{{Project: ref{ID: 20, Name: "Internal"}, TaskAssignments: []taskAssignment{{Task: ref{ID: 200, Name: "Meetings"}}}}},
+				}
+			case "/v2/time_entries":
+				q := r.URL.Query()
+				if q.Get("user_id") != "5" || q.Get("from") != "2024-03-01" || q.Get("to") != "2024-03-01" {
+					t.Errorf("unexpected time entry query: %s", r.URL.RawQuery)
+				}
+				return map[string]any{"next_page": nil, "time_entries": existing}
+			}
+			return nil
+		},
+	}
+}
+
+func testEvents() []*event.Event {
+	date := event.EventDate{Day: 1, Month: 3, Year: 2024}
+	return []*event.Event{
+		{Date: date, Hours: 2, Minutes: 30, Category: "acme", Task: "login page"},
+		{Date: date, Hours: 0, Minutes: 20, Category: "internal", Task: "standup"},
+	}
+}
+
+func newTestExporter(t *testing.T, fake *clienttest.FakeAPI) event.Exporter {
+	server := fake.NewServer()
+
+	exporter, err := NewExporter(map[string]string{
+		"apiUrl":           server.URL,
+		"accountId":        "123",
+		"apiToken":         "secret-token",
+		"project.acme":     "10",
+		"task.acme":        "100",
+		"project.internal": "20",
+		"task.internal":    "200",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+	return exporter
+}
+
+func TestExport(t *testing.T) {
+	fake := newFakeHarvest(t, []existingEntry{
+		{SpentDate: "2024-03-01", Hours: 2.5, Notes: "login page", Project: ref{ID: 10}, Task: ref{ID: 100}},
+	})
+	exporter := newTestExporter(t, fake)
+
+	err := exporter.Export(testEvents())
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	created := clienttest.Posted[timeEntry](fake, "/v2/time_entries")
+	if len(created) != 1 {
+		t.Fatalf("expected 1 created entry, got %d", len(created))
+	}
+
+	te := created[0]
+	if te.ProjectID != 20 || te.TaskID != 200 || te.Hours != 0.33 || te.SpentDate != "2024-03-01" || te.Notes != "standup" {
+		t.Errorf("unexpected entry: %+v", te)
+	}
+}
+
+func TestExportUnmapped(t *testing.T) {
+	exporter := newTestExporter(t, newFakeHarvest(t, nil))
+
+	err := exporter.Export([]*event.Event{{Hours: 1, Category: "unknown"}})
+	if err == nil {
+		t.Errorf("expected an error for an unmapped category")
+	}
+}
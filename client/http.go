@@ -1,9 +1,13 @@
 package client
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -48,3 +52,54 @@ func (hc *HttpClient) Do(req *http.Request) (*Resp, error) {
 		Header: hr.Header,
 	}, nil
 }
+
+// JSONClient sends requests to the endpoints of a JSON API, found under
+// BaseURL. Authorize adds the credentials to each request.
+type JSONClient struct {
+	Http      *HttpClient
+	BaseURL   *url.URL
+	Authorize func(req *http.Request)
+}
+
+// Request sends a request to the endpoint, with the body as JSON if there is
+// one. Responses outside the 2xx range are returned along with an error.
+func (jc *JSONClient) Request(method, endpoint string, params url.Values, body []byte) (*Resp, error) {
+	req, err := http.NewRequest(method, jc.BaseURL.JoinPath(endpoint).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequest: %w", err)
+	}
+	req.URL.RawQuery = params.Encode()
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if jc.Authorize != nil {
+		jc.Authorize(req)
+	}
+
+	resp, err := jc.Http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Code < 200 || resp.Code > 299 {
+		return resp, fmt.Errorf("%s %s: unexpected status %d: %s", method, endpoint, resp.Code, strings.TrimSpace(string(resp.Body)))
+	}
+
+	return resp, nil
+}
+
+// GetJSON sends a GET request to the endpoint, and unmarshals the response
+// into v.
+func (jc *JSONClient) GetJSON(endpoint string, params url.Values, v any) error {
+	resp, err := jc.Request(http.MethodGet, endpoint, params, nil)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(resp.Body, v)
+	if err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+// Client exports events to an iCalendar file. If the path is a directory, each
+// day is written to a file of its own. Exporting a day again replaces the
+// events that clocker wrote for that day, and leaves the rest of the file
+// alone.
+type Client struct {
+	// Configuration
+	Path         string // an .ics file, or a directory
+	CalendarName string
+
+	// State
+	dir bool
+	now func() time.Time
+}
+
+func (c *Client) Init() error {
+	if c.now == nil {
+		c.now = time.Now
+	}
+
+	c.dir = !strings.EqualFold(filepath.Ext(c.Path), ".ics")
+	if c.dir {
+		err := os.MkdirAll(c.Path, 0755)
+		if err != nil {
+			return fmt.Errorf("os.MkdirAll: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) Export(events []*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	byDate := map[string][]vevent{}
+	var dates []string
+	uids := map[string]int{}
+	stamp := c.now()
+	for _, e := range event.Timed(events) {
+		date := eventDate(e)
+		if _, ok := byDate[date]; !ok {
+			dates = append(dates, date)
+		}
+
+		id := uid(e, 0)
+		n := uids[id]
+		uids[id]++
+		if n > 0 {
+			id = uid(e, n)
+		}
+		byDate[date] = append(byDate[date], toVevent(e, id, stamp))
+	}
+
+	if c.dir {
+		for _, date := range dates {
+			path := filepath.Join(c.Path, "clocker-"+date+".ics")
+			err := c.write(path, byDate[date])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var added []vevent
+	replaced := map[string]bool{}
+	for _, date := range dates {
+		added = append(added, byDate[date]...)
+		replaced[date] = true
+	}
+
+	data, err := os.ReadFile(c.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c.write(c.Path, added)
+	}
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	merged, err := mergeCalendar(string(data), replaced, added)
+	if err != nil {
+		return fmt.Errorf("merging into %s: %w", c.Path, err)
+	}
+
+	err = os.WriteFile(c.Path, []byte(merged), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	fmt.Printf("Wrote %d calendar events to %s.\n", len(added), c.Path)
+	return nil
+}
+
+func (c *Client) write(path string, events []vevent) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("os.Create: %w", err)
+	}
+
+	err = writeCalendar(f, c.CalendarName, events)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("f.Close: %w", err)
+	}
+
+	fmt.Printf("Wrote %d calendar events to %s.\n", len(events), path)
+	return nil
+}
@@ -0,0 +1,37 @@
+package ical
+
+import (
+	"fmt"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "ical",
+		Description: "Writes the events to an iCalendar (.ics) file, or one file per day in a directory",
+		Required:    []string{"path"},
+		Optional:    []string{"calendarName"},
+		Factory:     NewExporter,
+	})
+}
+
+// NewExporter creates an iCalendar client from exporter params.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "path")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		Path:         p["path"],
+		CalendarName: params["calendarName"],
+	}
+
+	err = client.Init()
+	if err != nil {
+		return nil, fmt.Errorf("ical.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
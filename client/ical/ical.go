@@ -0,0 +1,253 @@
+package ical
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/format"
+)
+
+const (
+	utcLayout  = "20060102T150405Z"
+	dateLayout = "20060102"
+
+	// dateProperty marks the events written by clocker with the day they were
+	// logged on, so that exporting the day again can replace them.
+	dateProperty = "X-CLOCKER-DATE"
+
+	maxLineLength = 75 // octets, excluding the line break
+)
+
+// vevent is an event in a calendar, as its unfolded content lines.
+type vevent struct {
+	lines []string
+}
+
+// date returns the logged day of an event written by clocker, or "" for other
+// events.
+func (v *vevent) date() string {
+	for _, line := range v.lines {
+		if value, ok := strings.CutPrefix(line, dateProperty+":"); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func eventDate(e *event.Event) string {
+	return fmt.Sprintf("%04d-%02d-%02d", e.Date.Year, e.Date.Month, e.Date.Day)
+}
+
+// uid returns a stable ID for the event, so that calendar applications update
+// the event when it's exported again. n counts the earlier events of the
+// export that are otherwise alike, such as two 15 minute standups without a
+// start time, so that each of them gets an ID of its own.
+func uid(e *event.Event, n int) string {
+	start := ""
+	if !e.Start.IsZero() {
+		start = e.Start.UTC().Format(utcLayout)
+	}
+
+	fields := []string{eventDate(e), start, strings.ToLower(e.Category), strings.ToLower(e.Task)}
+	if n > 0 {
+		fields = append(fields, strconv.Itoa(n))
+	}
+
+	sum := sha1.Sum([]byte(strings.Join(fields, "|")))
+	return hex.EncodeToString(sum[:10]) + "@clocker"
+}
+
+func summary(e *event.Event) string {
+	if e.Task == "" {
+		return e.Category
+	}
+	return fmt.Sprintf("%s: %s", e.Category, e.Task)
+}
+
+// toVevent renders the event. Events without a start and end time are written
+// as all-day events, with the duration in the summary.
+func toVevent(e *event.Event, uid string, stamp time.Time) vevent {
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + uid,
+		"DTSTAMP:" + stamp.UTC().Format(utcLayout),
+	}
+
+	sum := summary(e)
+	if e.Start.IsZero() || e.End.IsZero() {
+		day := time.Date(e.Date.Year, time.Month(e.Date.Month), e.Date.Day, 0, 0, 0, 0, time.UTC)
+		lines = append(lines,
+			"DTSTART;VALUE=DATE:"+day.Format(dateLayout),
+			"DTEND;VALUE=DATE:"+day.AddDate(0, 0, 1).Format(dateLayout),
+		)
+		sum = fmt.Sprintf("%s (%s)", sum, format.DurationHM(e.Duration()))
+	} else {
+		lines = append(lines,
+			"DTSTART:"+e.Start.UTC().Format(utcLayout),
+			"DTEND:"+e.End.UTC().Format(utcLayout),
+		)
+	}
+
+	lines = append(lines, "SUMMARY:"+escape(sum))
+	if e.Category != "" {
+		lines = append(lines, "CATEGORIES:"+escape(e.Category))
+	}
+	if e.Note != "" {
+		lines = append(lines, "DESCRIPTION:"+escape(e.Note))
+	}
+	lines = append(lines,
+		"TRANSP:TRANSPARENT",
+		dateProperty+":"+eventDate(e),
+		"END:VEVENT",
+	)
+
+	return vevent{lines: lines}
+}
+
+// escape escapes a text value.
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold splits a content line into lines of at most 75 octets, without
+// splitting UTF-8 sequences. Continuation lines start with a space.
+func fold(line string) string {
+	var sb strings.Builder
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = maxLineLength - 1 // the leading space counts
+	}
+	sb.WriteString(line + "\r\n")
+	return sb.String()
+}
+
+// writeEvents writes the content lines of the events.
+func writeEvents(w io.Writer, events []vevent) error {
+	for _, v := range events {
+		for _, line := range v.lines {
+			_, err := io.WriteString(w, fold(line))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeCalendar writes a calendar with the events.
+func writeCalendar(w io.Writer, name string, events []vevent) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//clocker//clocker//EN",
+		"CALSCALE:GREGORIAN",
+	}
+	if name != "" {
+		lines = append(lines, "X-WR-CALNAME:"+escape(name))
+	}
+	for _, line := range lines {
+		_, err := io.WriteString(w, fold(line))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := writeEvents(w, events)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, fold("END:VCALENDAR"))
+	return err
+}
+
+// contentLine is a content line of a calendar file, unfolded, along with the
+// raw text it was read from.
+type contentLine struct {
+	text string
+	raw  string
+}
+
+// readLines splits a calendar file into its content lines.
+func readLines(data string) []contentLine {
+	var lines []contentLine
+	for data != "" {
+		raw, rest, found := strings.Cut(data, "\n")
+		if found {
+			raw += "\n"
+		}
+		data = rest
+
+		text := strings.TrimRight(raw, "\r\n")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			last := &lines[len(lines)-1]
+			last.text += text[1:]
+			last.raw += raw
+			continue
+		}
+		lines = append(lines, contentLine{text: text, raw: raw})
+	}
+	return lines
+}
+
+// mergeCalendar returns the calendar with the events added at its end, and
+// with the events written by clocker for the replaced dates removed. The rest
+// of the calendar, such as other events, time zones and to-dos, is kept as it
+// was.
+func mergeCalendar(data string, replaced map[string]bool, events []vevent) (string, error) {
+	var sb strings.Builder
+	var current *vevent
+	var currentRaw strings.Builder
+	ended := false
+	for _, line := range readLines(data) {
+		switch {
+		case line.text == "BEGIN:VEVENT":
+			current = &vevent{}
+			currentRaw.Reset()
+		case line.text == "END:VCALENDAR" && current == nil && !ended:
+			err := writeEvents(&sb, events)
+			if err != nil {
+				return "", err
+			}
+			ended = true
+		}
+
+		if current == nil {
+			sb.WriteString(line.raw)
+			continue
+		}
+
+		current.lines = append(current.lines, line.text)
+		currentRaw.WriteString(line.raw)
+		if line.text == "END:VEVENT" {
+			if !replaced[current.date()] {
+				sb.WriteString(currentRaw.String())
+			}
+			current = nil
+		}
+	}
+
+	if current != nil || !ended {
+		return "", fmt.Errorf("the calendar doesn't end with END:VCALENDAR")
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,208 @@
+package ical
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func TestFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("æ", 60)
+	folded := fold(line)
+
+	for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(l) > maxLineLength {
+			t.Errorf("line of %d octets: %q", len(l), l)
+		}
+	}
+
+	lines := readLines("BEGIN:VEVENT\r\n" + folded + "END:VEVENT\r\n")
+	if len(lines) != 3 || lines[1].text != line || lines[1].raw != folded {
+		t.Errorf("the folded line didn't unfold to the original: %q", lines)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	got := escape("a, b; c\\d\ne")
+	expect := `a\, b\; c\\d\ne`
+	if got != expect {
+		t.Errorf("expected %s, got %s", expect, got)
+	}
+}
+
+func testEvents(day int, tasks ...string) []*event.Event {
+	var events []*event.Event
+	start := time.Date(2024, time.March, day, 9, 0, 0, 0, time.UTC)
+	for _, task := range tasks {
+		e := &event.Event{
+			Date:     event.EventDate{Day: day, Month: 3, Year: 2024},
+			Start:    start,
+			End:      start.Add(time.Hour),
+			Category: "dev",
+			Task:     task,
+		}
+		e.DetermineHours()
+		events = append(events, e)
+		start = e.End
+	}
+	return events
+}
+
+func TestExportFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worked.ics")
+
+	// a calendar that wasn't written by clocker, with lines that aren't folded
+	// or ended the way clocker would write them
+	original := "BEGIN:VCALENDAR\r\nX-WR-CALNAME:Work\r\n" +
+		"BEGIN:VTIMEZONE\nTZID:Europe/Oslo\nEND:VTIMEZONE\n" +
+		"BEGIN:VEVENT\r\nUID:planned@example.com\r\nSUMMARY:Planning of\r\n  the week\r\nEND:VEVENT\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Expenses\r\nEND:VTODO\r\n"
+	err := os.WriteFile(path, []byte(original+"END:VCALENDAR\r\n"), 0600)
+	if err != nil {
+		t.Fatalf("os.WriteFile: %s", err)
+	}
+
+	client := &Client{Path: path, now: func() time.Time { return time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC) }}
+	err = client.Init()
+	if err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	exports := [][]*event.Event{
+		testEvents(1, "first"),
+		testEvents(2, "second", "third"),
+		testEvents(1, "first", "fourth"),
+	}
+	for _, events := range exports {
+		err = client.Export(events)
+		if err != nil {
+			t.Fatalf("Export: %s", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %s", err)
+	}
+	content := string(data)
+
+	if !strings.HasPrefix(content, original) {
+		t.Errorf("expected the calendar to start as it did:\n%s", content)
+	}
+
+	if strings.Count(content, "BEGIN:VEVENT") != 5 {
+		t.Errorf("expected 5 events, got %d:\n%s", strings.Count(content, "BEGIN:VEVENT"), content)
+	}
+
+	for _, expect := range []string{"UID:planned@example.com", "SUMMARY:dev: fourth", "DTSTART:20240301T100000Z", "X-CLOCKER-DATE:2024-03-02"} {
+		if !strings.Contains(content, expect) {
+			t.Errorf("expected %q in:\n%s", expect, content)
+		}
+	}
+
+	// the first event kept its UID across the exports
+	first := uid(testEvents(1, "first")[0], 0)
+	if strings.Count(content, "UID:"+first) != 1 {
+		t.Errorf("expected the UID %s once", first)
+	}
+}
+
+func TestExportDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "calendar")
+	client := &Client{Path: dir}
+	err := client.Init()
+	if err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	events := append(testEvents(1, "a"), &event.Event{
+		Date:     event.EventDate{Day: 2, Month: 3, Year: 2024},
+		Minutes:  30,
+		Category: "admin",
+	})
+	err = client.Export(events)
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "clocker-2024-03-02.ics"))
+	if err != nil {
+		t.Fatalf("os.ReadFile: %s", err)
+	}
+
+	for _, expect := range []string{"DTSTART;VALUE=DATE:20240302", "DTEND;VALUE=DATE:20240303", "SUMMARY:admin (30m)"} {
+		if !strings.Contains(string(data), expect) {
+			t.Errorf("expected %q in:\n%s", expect, data)
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "clocker-2024-03-01.ics"))
+	if err != nil {
+		t.Errorf("expected a file for the 1st: %s", err)
+	}
+}
+
+func TestExportForeignCalendar(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.ics")
+	original := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Planning\r\nEND:VEVENT\r\n"
+	err := os.WriteFile(path, []byte(original), 0600)
+	if err != nil {
+		t.Fatalf("os.WriteFile: %s", err)
+	}
+
+	client := &Client{Path: path}
+	err = client.Init()
+	if err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	err = client.Export(testEvents(1, "first"))
+	if err == nil {
+		t.Errorf("expected an error for a calendar without END:VCALENDAR")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %s", err)
+	}
+	if string(data) != original {
+		t.Errorf("expected the calendar to be left alone, got:\n%s", data)
+	}
+}
+
+func TestUniqueUIDs(t *testing.T) {
+	dir := t.TempDir()
+	client := &Client{Path: dir}
+	err := client.Init()
+	if err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	date := event.EventDate{Day: 1, Month: 3, Year: 2024}
+	err = client.Export([]*event.Event{
+		{Date: date, Minutes: 15, Category: "meeting", Task: "standup"},
+		{Date: date, Minutes: 15, Category: "meeting", Task: "standup"},
+	})
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "clocker-2024-03-01.ics"))
+	if err != nil {
+		t.Fatalf("os.ReadFile: %s", err)
+	}
+
+	uids := map[string]bool{}
+	for _, line := range readLines(string(data)) {
+		if id, ok := strings.CutPrefix(line.text, "UID:"); ok {
+			uids[id] = true
+		}
+	}
+	if len(uids) != 2 {
+		t.Errorf("expected 2 different UIDs, got %v", uids)
+	}
+}
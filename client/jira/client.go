@@ -0,0 +1,151 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/client"
+)
+
+const (
+	ModeJira  = "jira"  // post worklogs to Jira's own worklog API
+	ModeTempo = "tempo" // post worklogs to Tempo, which keeps its own worklogs
+)
+
+// Client exports events as worklogs on Jira issues, found by the issue keys in
+// the event tasks.
+type Client struct {
+	// Configuration
+	Mode         string
+	BaseURL      string // the Jira site, e.g. https://example.atlassian.net
+	Email        string // with ApiToken, for Jira Cloud
+	ApiToken     string
+	Token        string            // a personal access token, for Jira Server and Data Center
+	Projects     []string          // project keys to accept issue keys for, empty for all
+	Issues       map[string]string // fallback issue keys, by category
+	DefaultIssue string
+
+	TempoURL        string
+	TempoToken      string
+	AuthorAccountID string
+
+	// State
+	HttpClient *client.HttpClient
+	jira       *client.JSONClient
+	tempo      *client.JSONClient
+	keyPattern *regexp.Regexp
+	issueIDs   map[string]string          // by issue key, for Tempo
+	existing   map[string]map[string]bool // worklog identities, by issue key or date
+}
+
+func (c *Client) Init(ctx context.Context) error {
+	if c.HttpClient == nil {
+		c.HttpClient = client.NewHttpClient(10 * time.Second)
+	}
+	if c.Mode == "" {
+		c.Mode = ModeJira
+	}
+	if c.TempoURL == "" {
+		c.TempoURL = "https://api.tempo.io/"
+	}
+
+	baseURL, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid BaseURL: %w", err)
+	}
+	c.jira = &client.JSONClient{
+		Http:    c.HttpClient,
+		BaseURL: baseURL,
+		Authorize: func(req *http.Request) {
+			if c.Token != "" {
+				req.Header.Set("Authorization", "Bearer "+c.Token)
+			} else {
+				req.SetBasicAuth(c.Email, c.ApiToken)
+			}
+		},
+	}
+
+	if c.Token == "" && (c.Email == "" || c.ApiToken == "") {
+		return fmt.Errorf("either a token, or an email and an apiToken is required")
+	}
+
+	switch c.Mode {
+	case ModeJira:
+	case ModeTempo:
+		tempoURL, err := url.Parse(c.TempoURL)
+		if err != nil {
+			return fmt.Errorf("invalid TempoURL: %w", err)
+		}
+		c.tempo = &client.JSONClient{
+			Http:    c.HttpClient,
+			BaseURL: tempoURL,
+			Authorize: func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer "+c.TempoToken)
+			},
+		}
+		if c.TempoToken == "" || c.AuthorAccountID == "" {
+			return fmt.Errorf("the tempo mode requires a tempoToken and an authorAccountId")
+		}
+	default:
+		return fmt.Errorf("unknown mode %q - Use %s or %s", c.Mode, ModeJira, ModeTempo)
+	}
+
+	c.keyPattern = issueKeyPattern(c.Projects)
+	c.issueIDs = map[string]string{}
+	c.existing = map[string]map[string]bool{}
+	return nil
+}
+
+// issueKeyPattern matches issue keys such as ABC-123, limited to the project
+// keys if any are given. Keys are matched in upper case only, so that words
+// like utf-8 and covid-19 aren't taken for issue keys.
+func issueKeyPattern(projects []string) *regexp.Regexp {
+	project := `[A-Z][A-Z0-9_]+`
+	if len(projects) > 0 {
+		quoted := make([]string, len(projects))
+		for i, p := range projects {
+			quoted[i] = regexp.QuoteMeta(strings.ToUpper(p))
+		}
+		project = "(?:" + strings.Join(quoted, "|") + ")"
+	}
+
+	return regexp.MustCompile(`\b(` + project + `-\d+)\b`)
+}
+
+// IssueKey finds the first issue key in the text.
+func (c *Client) IssueKey(text string) (string, bool) {
+	m := c.keyPattern.FindStringSubmatch(text)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// issueID looks up the numeric ID of an issue, which Tempo requires.
+func (c *Client) issueID(key string) (string, error) {
+	if id, ok := c.issueIDs[key]; ok {
+		return id, nil
+	}
+
+	resp, err := c.jira.Request(http.MethodGet, "rest/api/2/issue/"+url.PathEscape(key), nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var issue struct {
+		ID string `json:"id"`
+	}
+	err = json.Unmarshal(resp.Body, &issue)
+	if err != nil {
+		return "", fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	c.issueIDs[key] = issue.ID
+	return issue.ID, nil
+}
@@ -0,0 +1,56 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "jira",
+		Description: "Jira worklogs, or Tempo worklogs with mode: tempo, on the issue keys found in the tasks",
+		Required:    []string{"baseUrl"},
+		Optional: []string{"mode", "email", "apiToken", "token", "projects", "defaultIssue", "issue.<category>",
+			"tempoToken", "tempoUrl", "authorAccountId"},
+		Factory: NewExporter,
+	})
+}
+
+// NewExporter creates and initializes a Jira client from exporter params.
+// Events without an issue key in their task fall back to "issue.<category>"
+// params, and then the defaultIssue param.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "baseUrl")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		Mode:            strings.ToLower(params["mode"]),
+		BaseURL:         p["baseUrl"],
+		Email:           params["email"],
+		ApiToken:        params["apiToken"],
+		Token:           params["token"],
+		Issues:          event.PrefixedParams(params, "issue."),
+		DefaultIssue:    params["defaultIssue"],
+		TempoURL:        params["tempoUrl"],
+		TempoToken:      params["tempoToken"],
+		AuthorAccountID: params["authorAccountId"],
+	}
+
+	for _, project := range strings.Split(params["projects"], ",") {
+		if project = strings.TrimSpace(project); project != "" {
+			client.Projects = append(client.Projects, project)
+		}
+	}
+
+	err = client.Init(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("jira.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,211 @@
+package jira
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/client/clienttest"
+	"github.com/sporadisk/clocker/event"
+)
+
+func TestIssueKey(t *testing.T) {
+	tests := []struct {
+		projects []string
+		text     string
+		key      string
+	}{
+		{nil, "ABC-123 fix login", "ABC-123"},
+		{nil, "fix login (ABC-9)", "ABC-9"},
+		{nil, "fix login (abc-9)", ""},
+		{nil, "review of PROJ_2-15 and ABC-1", "PROJ_2-15"},
+		{nil, "no key here", ""},
+		{nil, "fix utf-8 decoding in the covid-19 report", ""},
+		{[]string{"abc"}, "COVID-19 notes for ABC-7", "ABC-7"},
+		{[]string{"ABC"}, "covid-19 notes", ""},
+	}
+
+	for _, tc := range tests {
+		c := &Client{keyPattern: issueKeyPattern(tc.projects)}
+		key, _ := c.IssueKey(tc.text)
+		if key != tc.key {
+			t.Errorf("%q: expected %q, got %q", tc.text, tc.key, key)
+		}
+	}
+}
+
+// newFakeJira is a stand-in for the issue and worklog endpoints of Jira and
+// Tempo. Worklogs posted to the FAIL-1 issue fail.
+func newFakeJira(t *testing.T) *clienttest.FakeAPI {
+	fake := &clienttest.FakeAPI{
+		T: t,
+		Authorized: func(r *http.Request) bool {
+			auth := r.Header.Get("Authorization")
+			return auth == "Bearer jira-token" || auth == "Bearer tempo-token"
+		},
+		Fail: map[string]bool{"/rest/api/2/issue/FAIL-1/worklog": true},
+	}
+
+	fake.Get = func(r *http.Request) any {
+		path := r.URL.Path
+		switch {
+		case strings.HasPrefix(path, "/rest/api/2/issue/") && strings.HasSuffix(path, "/worklog"):
+			worklogs := clienttest.Posted[jiraWorklog](fake, path)
+			return map[string]any{"startAt": 0, "total": len(worklogs), "worklogs": worklogs}
+		case strings.HasPrefix(path, "/rest/api/2/issue/"):
+			return map[string]string{"id": "10042", "key": strings.TrimPrefix(path, "/rest/api/2/issue/")}
+		case strings.HasPrefix(path, "/4/worklogs/user/"):
+			var results []map[string]any
+			for _, wl := range clienttest.Posted[tempoWorklog](fake, "/4/worklogs") {
+				if wl.StartDate == r.URL.Query().Get("from") {
+					results = append(results, map[string]any{
+						"issue":            map[string]any{"id": wl.IssueID},
+						"startDate":        wl.StartDate,
+						"startTime":        wl.StartTime,
+						"timeSpentSeconds": wl.TimeSpentSeconds,
+					})
+				}
+			}
+			return map[string]any{"results": results, "metadata": map[string]any{"count": len(results)}}
+		}
+		return nil
+	}
+
+	return fake
+}
+
+func testEvents() []*event.Event {
+	start := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	date := event.EventDate{Day: 1, Month: 3, Year: 2024}
+	return []*event.Event{
+		{Date: date, Start: start, End: start.Add(90 * time.Minute), Hours: 1, Minutes: 30, Category: "dev", Task: "ABC-123 fix login"},
+		{Date: date, Hours: 0, Minutes: 15, Category: "meeting", Task: "standup"},
+		{Date: date, Hours: 0, Minutes: 30, Category: "admin", Task: "expenses"},
+	}
+}
+
+func TestExportJira(t *testing.T) {
+	fake := newFakeJira(t)
+	server := fake.NewServer()
+
+	exporter, err := NewExporter(map[string]string{
+		"baseUrl":         server.URL,
+		"token":           "jira-token",
+		"issue.meeting":   "abc-1",
+		"unrelated.param": "x",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	err = exporter.Export(testEvents())
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	fix := clienttest.Posted[jiraWorklog](fake, "/rest/api/2/issue/ABC-123/worklog")
+	if len(fix) != 1 || fix[0].TimeSpentSeconds != 5400 || fix[0].Started != "2024-03-01T09:00:00.000+0000" {
+		t.Errorf("unexpected worklogs on ABC-123: %v", fix)
+	}
+
+	standup := clienttest.Posted[jiraWorklog](fake, "/rest/api/2/issue/ABC-1/worklog")
+	if len(standup) != 1 || standup[0].TimeSpentSeconds != 900 {
+		t.Errorf("unexpected worklogs on ABC-1: %v", standup)
+	}
+
+	if fake.Paths() != 2 {
+		t.Errorf("expected the admin event to be skipped, got worklogs on %d issues", fake.Paths())
+	}
+}
+
+func TestExportTempo(t *testing.T) {
+	fake := newFakeJira(t)
+	server := fake.NewServer()
+
+	exporter, err := NewExporter(map[string]string{
+		"mode":            "tempo",
+		"baseUrl":         server.URL,
+		"token":           "jira-token",
+		"tempoUrl":        server.URL,
+		"tempoToken":      "tempo-token",
+		"authorAccountId": "user-1",
+		"defaultIssue":    "OPS-1",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	err = exporter.Export(testEvents())
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	worklogs := clienttest.Posted[tempoWorklog](fake, "/4/worklogs")
+	if len(worklogs) != 3 {
+		t.Fatalf("expected 3 worklogs, got %d", len(worklogs))
+	}
+
+	first := worklogs[0]
+	if first.IssueID != 10042 || first.StartDate != "2024-03-01" || first.StartTime != "09:00:00" || first.AuthorAccountID != "user-1" {
+		t.Errorf("unexpected worklog: %v", first)
+	}
+}
+
+func TestExportTwice(t *testing.T) {
+	for _, params := range []map[string]string{
+		{"baseUrl": "", "token": "jira-token", "defaultIssue": "OPS-1"},
+		{"mode": "tempo", "baseUrl": "", "token": "jira-token", "tempoUrl": "", "tempoToken": "tempo-token", "authorAccountId": "user-1", "defaultIssue": "OPS-1"},
+	} {
+		fake := newFakeJira(t)
+		server := fake.NewServer()
+
+		params["baseUrl"] = server.URL
+		if _, ok := params["tempoUrl"]; ok {
+			params["tempoUrl"] = server.URL
+		}
+
+		for range 2 {
+			exporter, err := NewExporter(params)
+			if err != nil {
+				t.Fatalf("NewExporter: %s", err)
+			}
+
+			err = exporter.Export(testEvents())
+			if err != nil {
+				t.Fatalf("Export: %s", err)
+			}
+		}
+
+		total := len(clienttest.Posted[jiraWorklog](fake, "/rest/api/2/issue/ABC-123/worklog")) +
+			len(clienttest.Posted[jiraWorklog](fake, "/rest/api/2/issue/OPS-1/worklog")) +
+			len(clienttest.Posted[tempoWorklog](fake, "/4/worklogs"))
+		if total != 3 {
+			t.Errorf("%s: expected 3 worklogs after exporting twice, got %d", params["mode"], total)
+		}
+	}
+}
+
+func TestExportFailure(t *testing.T) {
+	fake := newFakeJira(t)
+	server := fake.NewServer()
+
+	exporter, err := NewExporter(map[string]string{
+		"baseUrl":       server.URL,
+		"token":         "jira-token",
+		"issue.meeting": "FAIL-1",
+		"defaultIssue":  "OPS-1",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	err = exporter.Export(testEvents())
+	if err == nil {
+		t.Fatalf("expected the failed worklog to be reported")
+	}
+
+	if len(clienttest.Posted[jiraWorklog](fake, "/rest/api/2/issue/ABC-123/worklog")) != 1 || len(clienttest.Posted[jiraWorklog](fake, "/rest/api/2/issue/OPS-1/worklog")) != 1 {
+		t.Errorf("expected the other worklogs to be posted")
+	}
+}
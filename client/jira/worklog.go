@@ -0,0 +1,302 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+type jiraWorklog struct {
+	Started          string `json:"started"` // format: 2006-01-02T15:04:05.000-0700
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	Comment          string `json:"comment,omitempty"`
+}
+
+type jiraWorklogPage struct {
+	StartAt  int           `json:"startAt"`
+	Total    int           `json:"total"`
+	Worklogs []jiraWorklog `json:"worklogs"`
+}
+
+type tempoWorklog struct {
+	IssueID          int    `json:"issueId"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	StartDate        string `json:"startDate"` // format: YYYY-MM-DD
+	StartTime        string `json:"startTime,omitempty"`
+	Description      string `json:"description,omitempty"`
+	AuthorAccountID  string `json:"authorAccountId"`
+}
+
+type tempoWorklogPage struct {
+	Results []struct {
+		Issue struct {
+			ID int `json:"id"`
+		} `json:"issue"`
+		TimeSpentSeconds int    `json:"timeSpentSeconds"`
+		StartDate        string `json:"startDate"`
+		StartTime        string `json:"startTime"`
+	} `json:"results"`
+	Metadata struct {
+		Count int    `json:"count"`
+		Next  string `json:"next"`
+	} `json:"metadata"`
+}
+
+// Export posts a worklog for each event that has an issue key, either in its
+// task or through the fallback rules. Worklogs with the same issue, start and
+// duration as an existing one are skipped, so that exporting a day again
+// doesn't log it twice. A failed worklog doesn't stop the export: The events
+// without an issue key and the failed worklogs are listed at the end.
+func (c *Client) Export(events []*event.Event) error {
+	var skipped []*event.Event
+	var failed []string
+	posted, existing := 0, 0
+
+	for _, e := range events {
+		key, ok := c.issueFor(e)
+		if !ok {
+			skipped = append(skipped, e)
+			continue
+		}
+
+		var created bool
+		var err error
+		switch c.Mode {
+		case ModeTempo:
+			created, err = c.postTempoWorklog(key, e)
+		default:
+			created, err = c.postJiraWorklog(key, e)
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s on %s (%s): %s", e.Description(), key, e.Duration(), err.Error()))
+			continue
+		}
+
+		if created {
+			posted++
+		} else {
+			existing++
+		}
+	}
+
+	fmt.Printf("Posted %d worklogs, skipped %d that already existed.\n", posted, existing)
+	if len(skipped) > 0 {
+		fmt.Printf("Skipped %d events without an issue key:\n", len(skipped))
+		for _, e := range skipped {
+			fmt.Printf(" - %s (%s)\n", e.Description(), e.Duration())
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Printf("Failed to post %d worklogs:\n", len(failed))
+		for _, f := range failed {
+			fmt.Printf(" - %s\n", f)
+		}
+		return fmt.Errorf("%d of %d worklogs failed", len(failed), len(failed)+posted+existing)
+	}
+
+	return nil
+}
+
+// issueFor finds the issue to log the event on: the key in the task, then the
+// category's fallback issue, and then the default issue.
+func (c *Client) issueFor(e *event.Event) (string, bool) {
+	if key, ok := c.IssueKey(e.Task); ok {
+		return key, true
+	}
+
+	if key, ok := c.Issues[strings.ToLower(e.Category)]; ok {
+		return strings.ToUpper(key), true
+	}
+
+	if c.DefaultIssue != "" {
+		return strings.ToUpper(c.DefaultIssue), true
+	}
+
+	return "", false
+}
+
+// postJiraWorklog posts the worklog, unless the issue already has it.
+func (c *Client) postJiraWorklog(key string, e *event.Event) (created bool, err error) {
+	wl := jiraWorklog{
+		Started:          started(e).Format(jiraTimeLayout),
+		TimeSpentSeconds: int(e.Duration().Seconds()),
+		Comment:          e.Description(),
+	}
+
+	existing, err := c.jiraWorklogs(key)
+	if err != nil {
+		return false, fmt.Errorf("jiraWorklogs: %w", err)
+	}
+
+	id := jiraWorklogID(started(e), wl.TimeSpentSeconds)
+	if existing[id] {
+		return false, nil
+	}
+
+	body, err := json.Marshal(wl)
+	if err != nil {
+		return false, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	_, err = c.jira.Request(http.MethodPost, "rest/api/2/issue/"+url.PathEscape(key)+"/worklog", nil, body)
+	if err != nil {
+		return false, err
+	}
+
+	existing[id] = true
+	return true, nil
+}
+
+func jiraWorklogID(start time.Time, seconds int) string {
+	return fmt.Sprintf("%s/%d", start.UTC().Format(time.RFC3339), seconds)
+}
+
+// jiraWorklogs returns the identities of the worklogs on the issue.
+func (c *Client) jiraWorklogs(key string) (map[string]bool, error) {
+	if ids, ok := c.existing[key]; ok {
+		return ids, nil
+	}
+
+	ids := map[string]bool{}
+	for startAt := 0; ; {
+		query := url.Values{"startAt": {strconv.Itoa(startAt)}}
+		resp, err := c.jira.Request(http.MethodGet, "rest/api/2/issue/"+url.PathEscape(key)+"/worklog", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page jiraWorklogPage
+		err = json.Unmarshal(resp.Body, &page)
+		if err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+
+		for _, wl := range page.Worklogs {
+			start, err := time.Parse(jiraTimeLayout, wl.Started)
+			if err != nil {
+				continue // not one of ours
+			}
+			ids[jiraWorklogID(start, wl.TimeSpentSeconds)] = true
+		}
+
+		startAt += len(page.Worklogs)
+		if len(page.Worklogs) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	c.existing[key] = ids
+	return ids, nil
+}
+
+// postTempoWorklog posts the worklog, unless the author already has it.
+func (c *Client) postTempoWorklog(key string, e *event.Event) (created bool, err error) {
+	id, err := c.issueID(key)
+	if err != nil {
+		return false, fmt.Errorf("issueID: %w", err)
+	}
+
+	issueID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("unexpected issue ID %q: %w", id, err)
+	}
+
+	start := started(e)
+	wl := tempoWorklog{
+		IssueID:          issueID,
+		TimeSpentSeconds: int(e.Duration().Seconds()),
+		StartDate:        start.Format("2006-01-02"),
+		Description:      e.Description(),
+		AuthorAccountID:  c.AuthorAccountID,
+	}
+	if !e.Start.IsZero() {
+		wl.StartTime = start.Format("15:04:05")
+	}
+
+	existing, err := c.tempoWorklogs(wl.StartDate)
+	if err != nil {
+		return false, fmt.Errorf("tempoWorklogs: %w", err)
+	}
+
+	wlID := tempoWorklogID(issueID, wl.StartDate, wl.StartTime, wl.TimeSpentSeconds)
+	if existing[wlID] {
+		return false, nil
+	}
+
+	body, err := json.Marshal(wl)
+	if err != nil {
+		return false, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	_, err = c.tempo.Request(http.MethodPost, "4/worklogs", nil, body)
+	if err != nil {
+		return false, err
+	}
+
+	existing[wlID] = true
+	return true, nil
+}
+
+func tempoWorklogID(issueID int, date, startTime string, seconds int) string {
+	if startTime == "" {
+		startTime = "00:00:00"
+	}
+	return fmt.Sprintf("%d/%sT%s/%d", issueID, date, startTime, seconds)
+}
+
+// tempoWorklogs returns the identities of the author's worklogs on the date.
+func (c *Client) tempoWorklogs(date string) (map[string]bool, error) {
+	if ids, ok := c.existing[date]; ok {
+		return ids, nil
+	}
+
+	ids := map[string]bool{}
+	for offset := 0; ; {
+		query := url.Values{
+			"from":   {date},
+			"to":     {date},
+			"offset": {strconv.Itoa(offset)},
+			"limit":  {"1000"},
+		}
+		resp, err := c.tempo.Request(http.MethodGet, "4/worklogs/user/"+url.PathEscape(c.AuthorAccountID), query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page tempoWorklogPage
+		err = json.Unmarshal(resp.Body, &page)
+		if err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+
+		for _, wl := range page.Results {
+			ids[tempoWorklogID(wl.Issue.ID, wl.StartDate, wl.StartTime, wl.TimeSpentSeconds)] = true
+		}
+
+		offset += page.Metadata.Count
+		if page.Metadata.Next == "" || page.Metadata.Count == 0 {
+			break
+		}
+	}
+
+	c.existing[date] = ids
+	return ids, nil
+}
+
+// started returns the start of the event. Events without a start time start
+// at midnight.
+func started(e *event.Event) time.Time {
+	if !e.Start.IsZero() {
+		return e.Start
+	}
+	return time.Date(e.Date.Year, time.Month(e.Date.Month), e.Date.Day, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,169 @@
+package kimai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/client"
+)
+
+// Client exports events as Kimai timesheets, through the REST API.
+type Client struct {
+	// Configuration
+	ApiURL          string // the Kimai installation, e.g. https://kimai.example.com
+	ApiToken        string
+	Customers       map[string]string // customer name or ID, by category. Only used to find projects by name
+	Projects        map[string]string // project name or ID, by category
+	Activities      map[string]string // activity name or ID, by category
+	DefaultProject  string
+	DefaultActivity string
+
+	// State
+	HttpClient *client.HttpClient
+	api        *client.JSONClient
+	customers  []entity
+	projects   []entity
+	activities []entity
+	mappings   map[string]mapping // resolved IDs, by category
+	defaults   mapping
+}
+
+// entity is the common shape of Kimai customers, projects and activities.
+// Projects have a customer, and activities have a project, unless they are
+// global.
+type entity struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Customer *int   `json:"customer,omitempty"`
+	Project  *int   `json:"project,omitempty"`
+}
+
+type mapping struct {
+	project  int
+	activity int
+}
+
+func (c *Client) Init(ctx context.Context) error {
+	if c.HttpClient == nil {
+		c.HttpClient = client.NewHttpClient(10 * time.Second)
+	}
+
+	apiURL, err := url.Parse(c.ApiURL)
+	if err != nil {
+		return fmt.Errorf("invalid ApiURL: %w", err)
+	}
+	c.api = &client.JSONClient{
+		Http:    c.HttpClient,
+		BaseURL: apiURL.JoinPath("api"),
+		Authorize: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+c.ApiToken)
+		},
+	}
+
+	for endpoint, dst := range map[string]*[]entity{"customers": &c.customers, "projects": &c.projects, "activities": &c.activities} {
+		err = c.api.GetJSON(endpoint, nil, dst)
+		if err != nil {
+			return fmt.Errorf("getJSON(%s): %w", endpoint, err)
+		}
+	}
+
+	c.mappings = map[string]mapping{}
+	categories := map[string]bool{}
+	for cat := range c.Projects {
+		categories[cat] = true
+	}
+	for cat := range c.Activities {
+		categories[cat] = true
+	}
+
+	c.defaults, err = c.resolve("", c.DefaultProject, c.DefaultActivity, mapping{})
+	if err != nil {
+		return fmt.Errorf("defaults: %w", err)
+	}
+
+	for cat := range categories {
+		m, err := c.resolve(c.Customers[cat], c.Projects[cat], c.Activities[cat], c.defaults)
+		if err != nil {
+			return fmt.Errorf("category %q: %w", cat, err)
+		}
+		c.mappings[cat] = m
+	}
+
+	return nil
+}
+
+// resolve finds the IDs of the configured project and activity, with the
+// fallback used for anything that isn't configured.
+func (c *Client) resolve(customer, project, activity string, fallback mapping) (mapping, error) {
+	m := fallback
+
+	customerID := 0
+	if customer != "" {
+		id, err := find(c.customers, customer, nil)
+		if err != nil {
+			return m, fmt.Errorf("customer: %w", err)
+		}
+		customerID = id
+	}
+
+	if project != "" {
+		id, err := find(c.projects, project, func(e entity) bool {
+			return customerID == 0 || (e.Customer != nil && *e.Customer == customerID)
+		})
+		if err != nil {
+			return m, fmt.Errorf("project: %w", err)
+		}
+		m.project = id
+	}
+
+	// activities of the project take precedence over global activities with the
+	// same name
+	if activity != "" {
+		id, err := find(c.activities, activity, func(e entity) bool {
+			return e.Project != nil && *e.Project == m.project
+		})
+		if err != nil {
+			id, err = find(c.activities, activity, func(e entity) bool {
+				return e.Project == nil
+			})
+		}
+		if err != nil {
+			return m, fmt.Errorf("activity: %w", err)
+		}
+		m.activity = id
+	}
+
+	return m, nil
+}
+
+// find looks up an entity by name or ID. Names must be unambiguous among the
+// entities accepted by the filter.
+func find(entities []entity, nameOrID string, filter func(entity) bool) (int, error) {
+	var matches []int
+	for _, e := range entities {
+		if filter != nil && !filter(e) {
+			continue
+		}
+		if strings.EqualFold(e.Name, nameOrID) {
+			matches = append(matches, e.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		id, err := strconv.Atoi(nameOrID)
+		if err == nil {
+			return id, nil
+		}
+		return 0, fmt.Errorf("nothing called %q", nameOrID)
+	default:
+		return 0, fmt.Errorf("more than one called %q", nameOrID)
+	}
+}
@@ -0,0 +1,47 @@
+package kimai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "kimai",
+		Description: "Kimai timesheets, authorized with an API token",
+		Required:    []string{"apiUrl", "apiToken"},
+		Optional: []string{"defaultProject", "defaultActivity",
+			"customer.<category>", "project.<category>", "activity.<category>"},
+		Factory: NewExporter,
+	})
+}
+
+// NewExporter creates and initializes a Kimai client from exporter params.
+// Categories are mapped with "project.<category>" and "activity.<category>"
+// params, given by name or ID. A "customer.<category>" param picks between
+// projects with the same name.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "apiUrl", "apiToken")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		ApiURL:          p["apiUrl"],
+		ApiToken:        p["apiToken"],
+		Customers:       event.PrefixedParams(params, "customer."),
+		Projects:        event.PrefixedParams(params, "project."),
+		Activities:      event.PrefixedParams(params, "activity."),
+		DefaultProject:  params["defaultProject"],
+		DefaultActivity: params["defaultActivity"],
+	}
+
+	err = client.Init(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("kimai.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,115 @@
+package kimai
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/client/clienttest"
+	"github.com/sporadisk/clocker/event"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// newFakeKimai is a stand-in for the parts of the Kimai API used by the
+// client, with the existing timesheets of the user.
+func newFakeKimai(t *testing.T, existing []map[string]any) *clienttest.FakeAPI {
+	return &clienttest.FakeAPI{
+		T: t,
+		Authorized: func(r *http.Request) bool {
+			return r.Header.Get("Authorization") == "Bearer secret-token"
+		},
+		Get: func(r *http.Request) any {
+			switch r.URL.Path {
+			case "/api/customers":
+				return []entity{{ID: 1, Name: "Acme"}, {ID: 2, Name: "Globex"}}
+			case "/api/projects":
+				// both customers have a project called Support
+				return []entity{
+					{ID: 11, Name: "Support", Customer: intPtr(1)},
+					{ID: 21, Name: "Support", Customer: intPtr(2)},
+					{ID: 22, Name: "Website", Customer: intPtr(2)},
+				}
+			case "/api/activities":
+				return []entity{
+					{ID: 100, Name: "Development"},
+					{ID: 101, Name: "Meetings"},
+					{ID: 102, Name: "Development", Project: intPtr(22)},
+				}
+			case "/api/timesheets":
+				if r.URL.Query().Get("begin") != "2024-03-01T00:00:00" || r.URL.Query().Get("end") != "2024-03-02T00:00:00" {
+					t.Errorf("unexpected timesheet query: %s", r.URL.RawQuery)
+				}
+				return existing
+			}
+			return nil
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	fake := newFakeKimai(t, []map[string]any{
+		{"begin": "2024-03-01T08:00:00+0000", "end": "2024-03-01T10:00:00+0000", "project": 11, "activity": 101},
+		{"begin": "2024-03-01T14:00:00+0000", "end": nil, "project": 11, "activity": 101},
+	})
+	server := fake.NewServer()
+
+	exporter, err := NewExporter(map[string]string{
+		"apiUrl":           server.URL,
+		"apiToken":         "secret-token",
+		"defaultProject":   "11",
+		"defaultActivity":  "Meetings",
+		"customer.globex":  "Globex",
+		"project.globex":   "Support",
+		"project.web":      "Website",
+		"activity.web":     "Development",
+		"activity.unknown": "Meetings",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	at := func(hour int) time.Time {
+		return time.Date(2024, time.March, 1, hour, 0, 0, 0, time.UTC)
+	}
+	events := []*event.Event{
+		{Start: at(8), End: at(10), Category: "admin", Task: "already exported"},
+		{Start: at(10), End: at(11), Category: "globex", Task: "ticket"},
+		{Start: at(11), End: at(12), Category: "web", Task: "landing page"},
+		{Hours: 1, Category: "admin", Task: "no start time"},
+	}
+
+	err = exporter.Export(events)
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	expect := []timesheet{
+		{Begin: "2024-03-01T10:00:00", End: "2024-03-01T11:00:00", Project: 21, Activity: 101, Description: "ticket"},
+		{Begin: "2024-03-01T11:00:00", End: "2024-03-01T12:00:00", Project: 22, Activity: 102, Description: "landing page"},
+	}
+	created := clienttest.Posted[timesheet](fake, "/api/timesheets")
+	if len(created) != len(expect) {
+		t.Fatalf("expected %d timesheets, got %d: %+v", len(expect), len(created), created)
+	}
+	for i, ts := range expect {
+		if created[i] != ts {
+			t.Errorf("timesheet %d: expected %+v, got %+v", i, ts, created[i])
+		}
+	}
+}
+
+func TestAmbiguousProject(t *testing.T) {
+	server := newFakeKimai(t, nil).NewServer()
+
+	_, err := NewExporter(map[string]string{
+		"apiUrl":          server.URL,
+		"apiToken":        "secret-token",
+		"project.support": "Support",
+	})
+	if err == nil {
+		t.Errorf("expected an error for a project name shared by two customers")
+	}
+}
@@ -0,0 +1,141 @@
+package kimai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+// Kimai takes local times without a zone, and returns them with the zone of
+// the user.
+const (
+	postLayout = "2006-01-02T15:04:05"
+	getLayout  = "2006-01-02T15:04:05-0700"
+)
+
+type timesheet struct {
+	Begin       string `json:"begin"`
+	End         string `json:"end"`
+	Project     int    `json:"project"`
+	Activity    int    `json:"activity"`
+	Description string `json:"description,omitempty"`
+}
+
+// Export creates a timesheet for each event. Events that already have a
+// timesheet with the same begin, end, project and activity are skipped.
+func (c *Client) Export(events []*event.Event) error {
+	var sheets []*timesheet
+	var begins []time.Time
+	for _, e := range event.Timed(events) {
+		if e.Start.IsZero() || e.End.IsZero() {
+			fmt.Printf("Skipping %q: Kimai requires a start and end time\n", e.Description())
+			continue
+		}
+
+		m, ok := c.mappings[strings.ToLower(e.Category)]
+		if !ok {
+			m = c.defaults
+		}
+		if m.project == 0 || m.activity == 0 {
+			return fmt.Errorf("no Kimai project and activity for the category %q", e.Category)
+		}
+
+		sheets = append(sheets, &timesheet{
+			Begin:       e.Start.Format(postLayout),
+			End:         e.End.Format(postLayout),
+			Project:     m.project,
+			Activity:    m.activity,
+			Description: e.Description(),
+		})
+		begins = append(begins, e.Start)
+	}
+
+	if len(sheets) == 0 {
+		return nil
+	}
+
+	existing, err := c.existingTimesheets(begins)
+	if err != nil {
+		return fmt.Errorf("existingTimesheets: %w", err)
+	}
+
+	created, skipped := 0, 0
+	for _, ts := range sheets {
+		if existing[sheetKey(ts.Begin, ts.End, ts.Project, ts.Activity)] {
+			skipped++
+			continue
+		}
+
+		body, err := json.Marshal(ts)
+		if err != nil {
+			return fmt.Errorf("json.Marshal: %w", err)
+		}
+
+		_, err = c.api.Request(http.MethodPost, "timesheets", nil, body)
+		if err != nil {
+			return fmt.Errorf("creating timesheet %q: %w", ts.Description, err)
+		}
+		created++
+	}
+
+	fmt.Printf("Created %d Kimai timesheets, skipped %d that already existed.\n", created, skipped)
+	return nil
+}
+
+// existingTimesheets returns the keys of the user's timesheets on the days of
+// the events.
+func (c *Client) existingTimesheets(begins []time.Time) (map[string]bool, error) {
+	first, last := begins[0], begins[0]
+	for _, b := range begins {
+		first = minTime(first, b)
+		if b.After(last) {
+			last = b
+		}
+	}
+
+	from := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, first.Location())
+	to := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, last.Location())
+
+	params := url.Values{}
+	params.Set("begin", from.Format(postLayout))
+	params.Set("end", to.Format(postLayout))
+	params.Set("size", "1000")
+
+	var sheets []struct {
+		Begin    string `json:"begin"`
+		End      string `json:"end"`
+		Project  int    `json:"project"`
+		Activity int    `json:"activity"`
+	}
+	err := c.api.GetJSON("timesheets", params, &sheets)
+	if err != nil {
+		return nil, fmt.Errorf("getJSON(timesheets): %w", err)
+	}
+
+	keys := map[string]bool{}
+	for _, s := range sheets {
+		begin, err1 := time.Parse(getLayout, s.Begin)
+		end, err2 := time.Parse(getLayout, s.End)
+		if err1 != nil || err2 != nil {
+			continue // running timesheets have no end
+		}
+		keys[sheetKey(begin.Format(postLayout), end.Format(postLayout), s.Project, s.Activity)] = true
+	}
+	return keys, nil
+}
+
+func sheetKey(begin, end string, project, activity int) string {
+	return fmt.Sprintf("%s/%s/%d/%d", begin, end, project, activity)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,103 @@
+package logfile
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+const commandClock = "clock"
+
+// clock is a time span with full dates, as logged by dialects that record
+// intervals rather than individual clock-in and clock-out lines.
+type clock struct {
+	start      time.Time
+	end        time.Time // zero while the clock is still running
+	task       string    // "category: task", or just "category"
+	note       string
+	lineNumber int
+}
+
+// clockEntries converts clocks into log entries, in chronological order. A
+// set-day entry is emitted whenever the date changes.
+func clockEntries(clocks []clock) []logentry.Entry {
+	sort.SliceStable(clocks, func(i, j int) bool {
+		return clocks[i].start.Before(clocks[j].start)
+	})
+
+	entries := []logentry.Entry{}
+	currentDate := ""
+
+	for _, c := range splitAtMidnight(clocks) {
+		date := c.start.Format("2006-01-02")
+		if date != currentDate {
+			currentDate = date
+			entries = append(entries, logentry.Entry{
+				Action:     logentry.ActionSetDay,
+				Command:    logentry.ActionSetDay,
+				LineNumber: c.lineNumber,
+				DayName:    strings.ToLower(c.start.Weekday().String()),
+				Day:        c.start.Day(),
+				Month:      int(c.start.Month()),
+				Year:       c.start.Year(),
+			})
+		}
+
+		start := timeOfDay(c.start, c.start)
+		entries = append(entries, logentry.Entry{
+			Action:     logentry.ActionStartTask,
+			Command:    logentry.ActionStartTask,
+			Task:       c.task,
+			Note:       c.note,
+			Timestamp:  &start,
+			LineNumber: c.lineNumber,
+		})
+
+		if c.end.IsZero() {
+			continue
+		}
+
+		end := timeOfDay(c.start, c.end)
+		entries = append(entries, logentry.Entry{
+			Action:     logentry.ActionClockOut,
+			Command:    commandClock,
+			Timestamp:  &end,
+			LineNumber: c.lineNumber,
+		})
+	}
+
+	return entries
+}
+
+// splitAtMidnight splits clocks that span more than one date, so that each
+// part can be attributed to its own day.
+func splitAtMidnight(clocks []clock) []clock {
+	var result []clock
+	for _, c := range clocks {
+		for !c.end.IsZero() {
+			y, m, d := c.start.Date()
+			midnight := time.Date(y, m, d+1, 0, 0, 0, 0, c.start.Location())
+			if !c.end.After(midnight) {
+				break
+			}
+
+			part := c
+			part.end = midnight
+			result = append(result, part)
+			c.start = midnight
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// timeOfDay converts t into a date-less timestamp like the ones returned by
+// format.ParseTimestamp, relative to the date of day. A timestamp at midnight
+// after day is represented as 24:00, one day after the zero date.
+func timeOfDay(day, t time.Time) time.Time {
+	y, m, d := day.Date()
+	dayStart := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	return time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(t.Sub(dayStart))
+}
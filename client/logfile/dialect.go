@@ -0,0 +1,101 @@
+package logfile
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+// DefaultDialect is the name of the dialect implemented by LogParser.
+const DefaultDialect = "clocker"
+
+// Dialect parses the full text of a log file into log entries. Every dialect
+// produces the same kind of entries, so the calculator doesn't need to know
+// which one was used.
+type Dialect interface {
+	Parse(text string) []logentry.Entry
+}
+
+// DialectOptions holds the settings passed to every dialect factory.
+type DialectOptions struct {
+	Vocabulary *Vocabulary
+}
+
+type DialectFactory func(opts DialectOptions) (Dialect, error)
+
+type dialectSpec struct {
+	name       string
+	extensions []string
+	factory    DialectFactory
+}
+
+var dialects = map[string]dialectSpec{}
+
+// RegisterDialect makes a dialect available by name, and by the file
+// extensions it should be used for when no dialect has been configured.
+func RegisterDialect(name string, extensions []string, factory DialectFactory) {
+	name = strings.ToLower(name)
+	if _, exists := dialects[name]; exists {
+		panic(fmt.Sprintf("log dialect %q is already registered", name))
+	}
+
+	dialects[name] = dialectSpec{
+		name:       name,
+		extensions: extensions,
+		factory:    factory,
+	}
+}
+
+// Dialects returns the names of all registered dialects.
+func Dialects() []string {
+	var names []string
+	for name := range dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveDialect returns the name of the dialect to use for the file. The
+// configured name takes precedence, then the file extension. Files with
+// unrecognized extensions use the default dialect.
+func ResolveDialect(name, filePath string) (string, error) {
+	if name != "" {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := dialects[name]; !ok {
+			return "", fmt.Errorf("unknown log dialect %q - Available dialects: %s", name, strings.Join(Dialects(), ", "))
+		}
+		return name, nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if ext != "" {
+		for _, dname := range Dialects() {
+			for _, dext := range dialects[dname].extensions {
+				if ext == dext {
+					return dname, nil
+				}
+			}
+		}
+	}
+
+	return DefaultDialect, nil
+}
+
+// NewDialect creates a dialect by name.
+func NewDialect(name string, opts DialectOptions) (Dialect, error) {
+	spec, ok := dialects[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown log dialect %q", name)
+	}
+
+	d, err := spec.factory(opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s dialect: %w", spec.name, err)
+	}
+
+	return d, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sporadisk/clocker/config"
 	"github.com/sporadisk/clocker/logentry"
 )
 
@@ -16,6 +17,17 @@ func TestParseLine(t *testing.T) {
 		newTestLine("10:21 - Break").expectAction("off").expectTimestamp("10:21:00"),
 		newTestLine("Workday: 8h").expectAction("target").expectDuration("480m"),
 		newTestLine("Format: m").expectAction("outputformat"),
+		newTestLine("Meeting: standup +15m").expectAction("taskduration").expectDuration("15m").expectTask("Meeting: standup"),
+		newTestLine("Meeting: standup 15m").expectAction("taskduration").expectDuration("15m").expectTask("Meeting: standup"),
+		newTestLine("Support: customer call 1h 30m").expectAction("taskduration").expectDuration("1h30m").expectTask("Support: customer call"),
+		newTestLine("Note: follow up after +5m").expectAction("taskduration").expectDuration("5m"),
+		newTestLine("Note: rebooted the server after 5m").expectInvalid(),
+		newTestLine("TODO: call back in 2h").expectInvalid(),
+		newTestLine("+1h 30m Support: customer call").expectAction("taskduration").expectDuration("90m"),
+		newTestLine("Meeting: standup").expectInvalid(),
+		newTestLine("Flex: -30m").expectAction("flex").expectDuration("-30m"),
+		newTestLine("Flex: + 1h").expectAction("flex").expectDuration("60m"),
+		newTestLine("Flex day (dentist)").expectAction("flexday"),
 	}
 
 	lp := LogParser{}
@@ -25,6 +37,55 @@ func TestParseLine(t *testing.T) {
 		return
 	}
 
+	runLineTests(t, &lp, tests)
+}
+
+func TestParseLineVocabulary(t *testing.T) {
+	tests := []*testLine{
+		newTestLine("08:00 - Inn").expectAction("on").expectTimestamp("08:00:00"),
+		newTestLine("11:30 - Lunsj").expectAction("off").expectTimestamp("11:30:00"),
+		newTestLine("12:00 - Tilbake").expectAction("on").expectTimestamp("12:00:00"),
+		newTestLine("16:00 - Ferdig").expectAction("off").expectTimestamp("16:00:00"),
+		newTestLine("Arbeidsdag: 7h 30m").expectAction("target").expectDuration("450m"),
+		newTestLine("Fleks: 30m").expectAction("flex").expectDuration("30m"),
+		newTestLine("12:15 - Sjekkut").expectAction("off").expectTimestamp("12:15:00"),
+		newTestLine("13:00 - Back").expectAction("on").expectTimestamp("13:00:00"),
+		newTestLine("16:30 - Ut.").expectAction("off").expectTimestamp("16:30:00"),
+		newTestLine("Fleksidag").expectAction("flexday"),
+		newTestLine("Fri på").expectAction("flexday"),
+		newTestLine("Fri på.").expectAction("flexday"),
+		newTestLine("Fri påske").expectInvalid(),
+
+		// task names starting with a keyword
+		newTestLine("08:00 - Utvikling: ny modul").expectAction("starttask").expectTask("Utvikling: ny modul").expectTimestamp("08:00:00"),
+		newTestLine("10:00 - Innkjøp: lisenser").expectAction("starttask").expectTask("Innkjøp: lisenser").expectTimestamp("10:00:00"),
+		newTestLine("11:00 - Pausebord: x").expectAction("starttask").expectTask("Pausebord: x").expectTimestamp("11:00:00"),
+		newTestLine("14:00 - On-call").expectAction("starttask").expectTask("On-call").expectTimestamp("14:00:00"),
+	}
+
+	vocab, err := LoadVocabulary(&config.LogConfig{
+		Languages: []string{"nb", "en"},
+		Keywords: &config.KeywordConfig{
+			Stop:    []string{"sjekkut"},
+			FlexDay: []string{"fri på"},
+		},
+	})
+	if err != nil {
+		t.Errorf("LoadVocabulary: %s", err.Error())
+		return
+	}
+
+	lp := LogParser{Vocabulary: vocab}
+	err = lp.Init()
+	if err != nil {
+		t.Errorf("lp.Init: %s", err.Error())
+		return
+	}
+
+	runLineTests(t, &lp, tests)
+}
+
+func runLineTests(t *testing.T, lp *LogParser, tests []*testLine) {
 	for i, te := range tests {
 		t.Run(te.line, func(t *testing.T) {
 			valid, entry := lp.parseLine(te.line, i)
@@ -42,6 +103,11 @@ func TestParseLine(t *testing.T) {
 				return
 			}
 
+			if te.expected.Task != "" && entry.Task != te.expected.Task {
+				t.Errorf("task mismatch: expected %q, got %q", te.expected.Task, entry.Task)
+				return
+			}
+
 			if te.expected.Duration != nil {
 				if entry.Duration == nil {
 					t.Errorf("expected a duration, but it was nil")
@@ -105,6 +171,11 @@ func (tl *testLine) expectAction(action string) *testLine {
 	return tl
 }
 
+func (tl *testLine) expectTask(task string) *testLine {
+	tl.expected.Task = task
+	return tl
+}
+
 func (tl *testLine) expectTimestamp(ts string) *testLine {
 	timestamp, err := time.Parse("15:04:05", ts)
 	if err != nil {
@@ -0,0 +1,150 @@
+package logfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func init() {
+	// Plenty of logs in the default format live in .md files, so the dialect
+	// has to be selected explicitly.
+	RegisterDialect("markdown", nil, func(opts DialectOptions) (Dialect, error) {
+		mp := &MarkdownParser{Vocabulary: opts.Vocabulary}
+		err := mp.Init()
+		if err != nil {
+			return nil, fmt.Errorf("mp.Init: %w", err)
+		}
+		return mp, nil
+	})
+}
+
+const (
+	mdDateHeadingRegex = `^#{1,6}\s+(\d{4}-\d{2}-\d{2})\b`
+	mdListItemRegex    = `^(\s*)[-*+]\s+(?:\[[ xX]\]\s+)?(.*?)\s*$`
+	mdTimestampRegex   = `^(\d{1,2}:\d{2})\s+(?:-\s+)?(.+)$`
+)
+
+// MarkdownParser implements the Markdown journal dialect:
+//
+//	## 2025-10-17
+//	- 08:15 Dev: review
+//	  - nested bullets become the task's note
+//	- 11:30 break
+//
+// Top-level list items are interpreted like lines of the default dialect, so
+// the same keywords (and directives like "Flex: 1h") apply.
+type MarkdownParser struct {
+	Vocabulary *Vocabulary
+
+	lineParser         LogParser
+	dateHeadingPattern *regexp.Regexp
+	listItemPattern    *regexp.Regexp
+	timestampPattern   *regexp.Regexp
+}
+
+func (m *MarkdownParser) Init() error {
+	m.lineParser = LogParser{Vocabulary: m.Vocabulary}
+	err := m.lineParser.Init()
+	if err != nil {
+		return fmt.Errorf("lineParser.Init: %w", err)
+	}
+
+	m.dateHeadingPattern, err = regexp.Compile(mdDateHeadingRegex)
+	if err != nil {
+		return fmt.Errorf("failed to compile date heading pattern: %w", err)
+	}
+
+	m.listItemPattern, err = regexp.Compile(mdListItemRegex)
+	if err != nil {
+		return fmt.Errorf("failed to compile list item pattern: %w", err)
+	}
+
+	m.timestampPattern, err = regexp.Compile(mdTimestampRegex)
+	if err != nil {
+		return fmt.Errorf("failed to compile timestamp pattern: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MarkdownParser) Parse(text string) []logentry.Entry {
+	lines := strings.Split(text, "\n")
+	entries := []logentry.Entry{}
+	noteTarget := -1 // index of the entry that nested bullets are attached to
+
+	for i, line := range lines {
+		lineNumber := i + 1
+
+		dateMatches := m.dateHeadingPattern.FindStringSubmatch(line)
+		if dateMatches != nil {
+			noteTarget = -1
+			date, err := time.Parse("2006-01-02", dateMatches[1])
+			if err != nil {
+				m.lineParser.addWarningf("error parsing date heading on line %d: %s", lineNumber, err.Error())
+				continue
+			}
+
+			entries = append(entries, logentry.Entry{
+				Action:     logentry.ActionSetDay,
+				Command:    logentry.ActionSetDay,
+				LineNumber: lineNumber,
+				DayName:    strings.ToLower(date.Weekday().String()),
+				Day:        date.Day(),
+				Month:      int(date.Month()),
+				Year:       date.Year(),
+			})
+			continue
+		}
+
+		itemMatches := m.listItemPattern.FindStringSubmatch(line)
+		if itemMatches == nil {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			// any other paragraph ends the task's note
+			noteTarget = -1
+			continue
+		}
+
+		indent, content := itemMatches[1], itemMatches[2]
+		if indent != "" {
+			if noteTarget >= 0 && content != "" {
+				entries[noteTarget].Note = appendNote(entries[noteTarget].Note, content)
+			}
+			continue
+		}
+
+		noteTarget = -1
+
+		// "08:15 Dev: review" is written as "08:15 - Dev: review" in the
+		// default dialect
+		tsMatches := m.timestampPattern.FindStringSubmatch(content)
+		if tsMatches != nil {
+			content = tsMatches[1] + " - " + tsMatches[2]
+		}
+
+		lineEntries := m.lineParser.parseLineEntries(content, lineNumber)
+		if len(lineEntries) == 0 {
+			continue
+		}
+
+		entries = append(entries, lineEntries...)
+		switch lineEntries[0].Action {
+		case logentry.ActionStartTask, logentry.ActionClockIn, logentry.ActionRangeStart:
+			noteTarget = len(entries) - len(lineEntries)
+		}
+	}
+
+	return entries
+}
+
+func appendNote(note, text string) string {
+	if note == "" {
+		return text
+	}
+	return note + "; " + text
+}
@@ -0,0 +1,69 @@
+package logfile
+
+import (
+	"testing"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	testText := `
+# Journal
+
+## 2025-10-17
+
+Slow start today.
+
+- Workday: 7h 30m
+- 08:15 Dev: review
+  - went through the parser changes
+  - left comments on the exporter
+- 11:30 break
+- [x] 12:00 - Meeting: sprint review
+- 13:00 done
+
+* an unrelated list item
+`
+
+	expectedActions := []string{
+		logentry.ActionSetDay,
+		logentry.ActionTarget,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+	}
+	expectedTasks := []string{"", "", "Dev: review", "", "Meeting: sprint review", ""}
+	expectedNotes := []string{"", "", "went through the parser changes; left comments on the exporter", "", "", ""}
+
+	mp := MarkdownParser{}
+	err := mp.Init()
+	if err != nil {
+		t.Errorf("mp.Init: %s", err.Error())
+		return
+	}
+
+	entries := mp.Parse(testText)
+	if len(entries) != len(expectedActions) {
+		t.Errorf("entry length mismatch: expected %d, got %d", len(expectedActions), len(entries))
+		return
+	}
+
+	for i, entry := range entries {
+		if entry.Action != expectedActions[i] {
+			t.Errorf("wrong action for entry %d: expected %q, got %q", i, expectedActions[i], entry.Action)
+		}
+
+		if entry.Task != expectedTasks[i] {
+			t.Errorf("wrong task for entry %d: expected %q, got %q", i, expectedTasks[i], entry.Task)
+		}
+
+		if entry.Note != expectedNotes[i] {
+			t.Errorf("wrong note for entry %d: expected %q, got %q", i, expectedNotes[i], entry.Note)
+		}
+	}
+
+	if entries[0].Day != 17 || entries[0].Month != 10 || entries[0].Year != 2025 || entries[0].DayName != "friday" {
+		t.Errorf("wrong date: got %s %02d.%02d.%04d", entries[0].DayName, entries[0].Day, entries[0].Month, entries[0].Year)
+	}
+}
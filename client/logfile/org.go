@@ -0,0 +1,181 @@
+package logfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func init() {
+	RegisterDialect("org", []string{".org"}, func(opts DialectOptions) (Dialect, error) {
+		op := &OrgParser{}
+		err := op.Init()
+		if err != nil {
+			return nil, fmt.Errorf("op.Init: %w", err)
+		}
+		return op, nil
+	})
+}
+
+const (
+	orgHeadlineRegex = `^(\*+)\s+(.*)$`
+	orgKeywordRegex  = `^(TODO|NEXT|STARTED|WAITING|HOLD|DONE|CANCELLED|CANCELED)\s+`
+	orgPriorityRegex = `^\[#[A-Za-z0-9]\]\s+`
+	orgTagsRegex     = `\s+:([\p{L}\p{N}_@#%:]+):\s*$`
+
+	// CLOCK: [2025-10-17 Fri 08:15]--[2025-10-17 Fri 11:35] =>  3:20
+	orgTimestamp  = `\[(\d{4}-\d{2}-\d{2})(?:\s+[^\]\s\d]+)?\s+(\d{1,2}:\d{2})\]`
+	orgClockRegex = `^\s*CLOCK:\s*` + orgTimestamp + `(?:\s*--\s*` + orgTimestamp + `)?`
+)
+
+// OrgParser implements the org-mode dialect. Each CLOCK line becomes a
+// clock-in and a clock-out, categorized by the headlines it's filed under.
+//
+// If the headline or one of its ancestors has tags, the nearest tag is used as
+// the category, and the headline path as the task. Otherwise the top-level
+// headline is used as the category, and the rest of the path as the task.
+type OrgParser struct {
+	headlinePattern *regexp.Regexp
+	keywordPattern  *regexp.Regexp
+	priorityPattern *regexp.Regexp
+	tagsPattern     *regexp.Regexp
+	clockPattern    *regexp.Regexp
+	warnings        []string
+}
+
+type orgHeadline struct {
+	level int
+	title string
+	tags  []string
+}
+
+func (o *OrgParser) Init() error {
+	patterns := []struct {
+		name  string
+		regex string
+		dst   **regexp.Regexp
+	}{
+		{"headline", orgHeadlineRegex, &o.headlinePattern},
+		{"keyword", orgKeywordRegex, &o.keywordPattern},
+		{"priority", orgPriorityRegex, &o.priorityPattern},
+		{"tags", orgTagsRegex, &o.tagsPattern},
+		{"clock", orgClockRegex, &o.clockPattern},
+	}
+
+	for _, p := range patterns {
+		compiled, err := regexp.Compile(p.regex)
+		if err != nil {
+			return fmt.Errorf("failed to compile %s pattern: %w", p.name, err)
+		}
+		*p.dst = compiled
+	}
+
+	o.warnings = []string{}
+	return nil
+}
+
+func (o *OrgParser) Parse(text string) []logentry.Entry {
+	lines := strings.Split(text, "\n")
+	path := []orgHeadline{}
+	clocks := []clock{}
+
+	for i, line := range lines {
+		headlineMatches := o.headlinePattern.FindStringSubmatch(line)
+		if headlineMatches != nil {
+			headline := o.parseHeadline(len(headlineMatches[1]), headlineMatches[2])
+
+			// drop the siblings and descendants of the new headline
+			for len(path) > 0 && path[len(path)-1].level >= headline.level {
+				path = path[:len(path)-1]
+			}
+			path = append(path, headline)
+			continue
+		}
+
+		clockMatches := o.clockPattern.FindStringSubmatch(line)
+		if clockMatches == nil {
+			continue
+		}
+
+		start, err := parseOrgTimestamp(clockMatches[1], clockMatches[2])
+		if err != nil {
+			o.addWarningf("error parsing clock start on line %d: %s", i+1, err.Error())
+			continue
+		}
+
+		c := clock{
+			start:      start,
+			task:       orgTask(path),
+			lineNumber: i + 1,
+		}
+
+		if clockMatches[3] != "" {
+			end, err := parseOrgTimestamp(clockMatches[3], clockMatches[4])
+			if err != nil {
+				o.addWarningf("error parsing clock end on line %d: %s", i+1, err.Error())
+				continue
+			}
+			c.end = end
+		}
+
+		clocks = append(clocks, c)
+	}
+
+	return clockEntries(clocks)
+}
+
+func (o *OrgParser) parseHeadline(level int, text string) orgHeadline {
+	headline := orgHeadline{level: level}
+
+	tagMatches := o.tagsPattern.FindStringSubmatch(text)
+	if tagMatches != nil {
+		for _, tag := range strings.Split(tagMatches[1], ":") {
+			if tag != "" {
+				headline.tags = append(headline.tags, tag)
+			}
+		}
+		text = text[:len(text)-len(tagMatches[0])]
+	}
+
+	text = o.keywordPattern.ReplaceAllString(text, "")
+	text = o.priorityPattern.ReplaceAllString(text, "")
+	headline.title = strings.TrimSpace(text)
+	return headline
+}
+
+// orgTask builds the "category: task" string for a clock filed under the
+// headline path.
+func orgTask(path []orgHeadline) string {
+	if len(path) == 0 {
+		return ""
+	}
+
+	titles := make([]string, len(path))
+	for i, h := range path {
+		titles[i] = h.title
+	}
+
+	// the nearest tag wins, like tag inheritance in org-mode
+	for i := len(path) - 1; i >= 0; i-- {
+		if len(path[i].tags) > 0 {
+			return path[i].tags[0] + ": " + strings.Join(titles, " / ")
+		}
+	}
+
+	if len(titles) == 1 {
+		return titles[0]
+	}
+
+	return titles[0] + ": " + strings.Join(titles[1:], " / ")
+}
+
+func parseOrgTimestamp(date, clockTime string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04", date+" "+clockTime, time.Local)
+}
+
+func (o *OrgParser) addWarningf(format string, v ...any) {
+	o.warnings = append(o.warnings, fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,96 @@
+package logfile
+
+import (
+	"testing"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func TestParseOrg(t *testing.T) {
+	testText := `
+#+TITLE: Work
+* Project Apollo
+** TODO [#A] Review pull requests
+   :LOGBOOK:
+   CLOCK: [2025-10-17 Fri 12:15]--[2025-10-17 Fri 13:00] =>  0:45
+   CLOCK: [2025-10-17 Fri 08:15]--[2025-10-17 Fri 11:35] =>  3:20
+   :END:
+* Sprint review                                                   :meeting:
+  CLOCK: [2025-10-17 Fri 13:00]--[2025-10-17 Fri 14:00] =>  1:00
+* Support
+  CLOCK: [2025-10-18 Sat 23:30]--[2025-10-19 Sun 00:30] =>  1:00
+`
+
+	expectedActions := []string{
+		logentry.ActionSetDay,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionSetDay,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionSetDay,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+	}
+	expectedTasks := []string{
+		"",
+		"Project Apollo: Review pull requests",
+		"",
+		"Project Apollo: Review pull requests",
+		"",
+		"meeting: Sprint review",
+		"",
+		"",
+		"Support",
+		"",
+		"",
+		"Support",
+		"",
+	}
+	expectedTimes := []string{"", "08:15", "11:35", "12:15", "13:00", "13:00", "14:00", "", "23:30", "00:00", "", "00:00", "00:30"}
+
+	op := OrgParser{}
+	err := op.Init()
+	if err != nil {
+		t.Errorf("op.Init: %s", err.Error())
+		return
+	}
+
+	entries := op.Parse(testText)
+	if len(entries) != len(expectedActions) {
+		t.Errorf("entry length mismatch: expected %d, got %d", len(expectedActions), len(entries))
+		return
+	}
+
+	for i, entry := range entries {
+		if entry.Action != expectedActions[i] {
+			t.Errorf("wrong action for entry %d: expected %q, got %q", i, expectedActions[i], entry.Action)
+		}
+
+		if entry.Task != expectedTasks[i] {
+			t.Errorf("wrong task for entry %d: expected %q, got %q", i, expectedTasks[i], entry.Task)
+		}
+
+		if entry.Timestamp != nil && entry.Timestamp.Format("15:04") != expectedTimes[i] {
+			t.Errorf("wrong time for entry %d: expected %s, got %s", i, expectedTimes[i], entry.Timestamp.Format("15:04"))
+		}
+	}
+
+	// the clock crossing midnight should end at 24:00 on the first day
+	if entries[9].Timestamp.YearDay() != 2 {
+		t.Errorf("expected the first part of the split clock to end after midnight")
+	}
+
+	if entries[10].Day != 19 || entries[10].Month != 10 || entries[10].Year != 2025 {
+		t.Errorf("wrong date for entry 10: got %02d.%02d.%04d", entries[10].Day, entries[10].Month, entries[10].Year)
+	}
+
+	name, err := ResolveDialect("", "notes/work.org")
+	if err != nil || name != "org" {
+		t.Errorf("expected .org files to resolve to the org dialect, got %q (%v)", name, err)
+	}
+}
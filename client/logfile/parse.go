@@ -15,26 +15,92 @@ func (l *LogParser) Parse(text string) []logentry.Entry {
 	entries := []logentry.Entry{}
 
 	for i, line := range lines {
-		valid, entry := l.parseLine(line, i+1)
-		if valid {
-			entries = append(entries, entry)
-		}
+		entries = append(entries, l.parseLineEntries(line, i+1)...)
 	}
 
 	return entries
 }
 
+// parseLineEntries parses a line that may produce more than one entry, such
+// as a time range.
+func (l *LogParser) parseLineEntries(text string, lineNumber int) []logentry.Entry {
+	rangeMatches := l.rangePattern.FindStringSubmatch(text)
+	if rangeMatches != nil {
+		entries, err := parseRange(rangeMatches[1], rangeMatches[2], strings.TrimSpace(rangeMatches[3]), lineNumber)
+		if err == nil {
+			return entries
+		}
+		l.addWarningf("error parsing time range %#v: %s", rangeMatches[0], err.Error())
+	}
+
+	valid, entry := l.parseLine(text, lineNumber)
+	if valid {
+		return []logentry.Entry{entry}
+	}
+
+	return nil
+}
+
+// parseRange creates the paired entries for a "09:00-10:30 category: task"
+// line. Ranges ending before they start are assumed to end after midnight.
+func parseRange(startStr, endStr, task string, lineNumber int) ([]logentry.Entry, error) {
+	start, err := format.ParseTimestamp(startStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time: %w", err)
+	}
+
+	end, err := format.ParseTimestamp(endStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end time: %w", err)
+	}
+
+	if end.Equal(start) {
+		return nil, fmt.Errorf("the range is empty")
+	}
+
+	if end.Before(start) {
+		end = end.Add(24 * time.Hour)
+	}
+
+	return []logentry.Entry{
+		{
+			Action:     logentry.ActionRangeStart,
+			Command:    commandRange,
+			Task:       task,
+			Timestamp:  &start,
+			LineNumber: lineNumber,
+		},
+		{
+			Action:     logentry.ActionRangeEnd,
+			Command:    commandRange,
+			Timestamp:  &end,
+			LineNumber: lineNumber,
+		},
+	}, nil
+}
+
+// The start, stop, flex, target and output patterns are templates: The %s
+// verb is replaced by the keywords from the parser's Vocabulary. Keywords must
+// be followed by a boundary, so that "Innkjøp" isn't read as "inn". The
+// boundary is spelled out, as \b only knows ASCII letters.
 const (
-	startPatternRegex         = `(?i)^\s*(\d+:\d+)\s+-\s+(start|on|back|resume)`
-	stopPatternRegex          = `(?i)^\s*(\d+:\d+)\s+-\s+(stop|break|pause|done|off|end)`
+	keywordBoundary           = `(?:[^\p{L}\p{N}_-]|$)`
+	startPatternRegex         = `(?i)^\s*(\d+:\d+)\s+-\s+(%s)` + keywordBoundary
+	stopPatternRegex          = `(?i)^\s*(\d+:\d+)\s+-\s+(%s)` + keywordBoundary
 	categorizedTimestampRegex = `^\s*(\d+:\d+)\s+-\s+(.+)` // any other timestamped line
-	flexPatternRegex          = `(?i)^\s*flex:\s*([\dhm ]+)`
-	targetPatternRegex        = `(?i)^\s*(target|full day|workday):\s*([\dhm ]+)`
-	outputPatternRegex        = `(?i)^\s*(output|format):\s*(hms|hm|m)`
+	rangePatternRegex         = `^\s*(\d+:\d+)\s*-\s*(\d+:\d+)\s+(.+)`
+	durationPrefixRegex       = `^\s*\+\s*(\d+h(?:\s*\d+m)?|\d+m)\s+(.+?)\s*$`          // "+45m Support: customer call"
+	durationSuffixRegex       = `^\s*([^:]+:.*?)\s+(\+\s*)?(\d+h(?:\s*\d+m)?|\d+m)\s*$` // "Meeting: standup 15m" or "Meeting: standup +15m"
+	flexPatternRegex          = `(?i)^\s*(?:%s):\s*([-+]?)\s*([\dhm ]+)`                // a negative sign puts time into the flex balance
+	flexDayPatternRegex       = `(?i)^\s*(%s)` + keywordBoundary
+	targetPatternRegex        = `(?i)^\s*(%s):\s*([\dhm ]+)`
+	outputPatternRegex        = `(?i)^\s*(%s):\s*(hms|hm|m)`
 	fullDatePatternRegex      = `^\s*--\s*(\p{L}+)\s+(\d+)\.(\d+)\.(\d+)`
 	dayMonthPatternRegex      = `^\s*--\s*(\p{L}+)\s+(\d+)\.(\d+)`
 
-	commandFlex = "flex"
+	commandFlex     = "flex"
+	commandRange    = "range"
+	commandDuration = "duration"
 )
 
 func (l *LogParser) parseLine(text string, lineNumber int) (valid bool, entry logentry.Entry) {
@@ -83,9 +149,20 @@ func (l *LogParser) parseLine(text string, lineNumber int) (valid bool, entry lo
 		}
 	}
 
+	flexDayMatches := l.flexDayPattern.FindStringSubmatch(text)
+	if flexDayMatches != nil {
+		entry.Action = logentry.ActionFlexDay
+		entry.Command = flexDayMatches[1]
+		entry.LineNumber = lineNumber
+		return true, entry
+	}
+
 	flexMatches := l.flexPattern.FindStringSubmatch(text)
 	if flexMatches != nil {
-		d, err := format.ParseDuration(flexMatches[1])
+		d, err := format.ParseDuration(flexMatches[2])
+		if flexMatches[1] == "-" {
+			d = -d
+		}
 		if err == nil {
 			entry.Action = logentry.ActionFlex
 			entry.Command = commandFlex
@@ -93,7 +170,7 @@ func (l *LogParser) parseLine(text string, lineNumber int) (valid bool, entry lo
 			entry.Duration = &d
 			return true, entry
 		} else {
-			l.addWarningf("error parsing flex duration from value %#v: %s", flexMatches[1], err.Error())
+			l.addWarningf("error parsing flex duration from value %#v: %s", flexMatches[2], err.Error())
 		}
 	}
 
@@ -153,9 +230,54 @@ func (l *LogParser) parseLine(text string, lineNumber int) (valid bool, entry lo
 		return true, entry
 	}
 
+	// Duration-only task lines are checked last, since the suffix form would
+	// otherwise match the target and flex lines.
+	durPrefixMatches := l.durationPrefixPattern.FindStringSubmatch(text)
+	if durPrefixMatches != nil {
+		return l.taskDuration(durPrefixMatches[1], durPrefixMatches[2], lineNumber)
+	}
+
+	durSuffixMatches := l.durationSuffixPattern.FindStringSubmatch(text)
+	if durSuffixMatches != nil && (durSuffixMatches[2] != "" || !l.connected(durSuffixMatches[1])) {
+		return l.taskDuration(durSuffixMatches[3], durSuffixMatches[1], lineNumber)
+	}
+
 	return false, logentry.Entry{}
 }
 
+// connected reports whether the text ends in a connective, which makes a bare
+// duration after it part of the text, as in "Note: rebooted the server after
+// 5m". A + marker always makes the duration the task's.
+func (l *LogParser) connected(text string) bool {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return false
+	}
+
+	last := words[len(words)-1]
+	for _, c := range l.Vocabulary.Connectives {
+		if last == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LogParser) taskDuration(durationStr, task string, lineNumber int) (valid bool, entry logentry.Entry) {
+	d, err := format.ParseDuration(durationStr)
+	if err != nil {
+		l.addWarningf("error parsing task duration from value %#v: %s", durationStr, err.Error())
+		return false, logentry.Entry{}
+	}
+
+	entry.Action = logentry.ActionTaskDuration
+	entry.Command = commandDuration
+	entry.LineNumber = lineNumber
+	entry.Task = strings.TrimSpace(task)
+	entry.Duration = &d
+	return true, entry
+}
+
 func (l *LogParser) addWarningf(format string, v ...any) {
 	l.addWarning(fmt.Sprintf(format, v...))
 }
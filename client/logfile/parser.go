@@ -7,27 +7,58 @@ import (
 	"github.com/sporadisk/clocker/format"
 )
 
+func init() {
+	RegisterDialect(DefaultDialect, []string{".txt", ".log"}, func(opts DialectOptions) (Dialect, error) {
+		lp := &LogParser{Vocabulary: opts.Vocabulary}
+		err := lp.Init()
+		if err != nil {
+			return nil, fmt.Errorf("lp.Init: %w", err)
+		}
+		return lp, nil
+	})
+}
+
+// LogParser implements the default log dialect, where each line is matched
+// against a set of patterns.
 type LogParser struct {
-	startPattern    *regexp.Regexp
-	stopPattern     *regexp.Regexp
-	catTsPattern    *regexp.Regexp
-	flexPattern     *regexp.Regexp
-	targetPattern   *regexp.Regexp
-	outputPattern   *regexp.Regexp
-	fullDatePattern *regexp.Regexp
-	dayMonthPattern *regexp.Regexp
-	warnings        []string
-	outputFormat    string // the format to use for durations
+	Vocabulary            *Vocabulary // the keywords to recognize, defaults to the "en" language pack
+	startPattern          *regexp.Regexp
+	stopPattern           *regexp.Regexp
+	catTsPattern          *regexp.Regexp
+	rangePattern          *regexp.Regexp
+	durationPrefixPattern *regexp.Regexp
+	durationSuffixPattern *regexp.Regexp
+	flexPattern           *regexp.Regexp
+	flexDayPattern        *regexp.Regexp
+	targetPattern         *regexp.Regexp
+	outputPattern         *regexp.Regexp
+	fullDatePattern       *regexp.Regexp
+	dayMonthPattern       *regexp.Regexp
+	warnings              []string
+	outputFormat          string // the format to use for durations
 }
 
 func (l *LogParser) Init() error {
-	startPattern, err := regexp.Compile(startPatternRegex)
+	if l.Vocabulary == nil {
+		vocab, err := LoadVocabulary(nil)
+		if err != nil {
+			return fmt.Errorf("LoadVocabulary: %w", err)
+		}
+		l.Vocabulary = vocab
+	}
+
+	err := l.validateVocabulary()
+	if err != nil {
+		return fmt.Errorf("invalid vocabulary: %w", err)
+	}
+
+	startPattern, err := regexp.Compile(fmt.Sprintf(startPatternRegex, alternation(l.Vocabulary.Start)))
 	if err != nil {
 		return fmt.Errorf("failed to compile start pattern: %w", err)
 	}
 	l.startPattern = startPattern
 
-	stopPattern, err := regexp.Compile(stopPatternRegex)
+	stopPattern, err := regexp.Compile(fmt.Sprintf(stopPatternRegex, alternation(l.Vocabulary.Stop)))
 	if err != nil {
 		return fmt.Errorf("failed to compile stop pattern: %w", err)
 	}
@@ -39,6 +70,24 @@ func (l *LogParser) Init() error {
 	}
 	l.catTsPattern = catTimestampPattern
 
+	rangePattern, err := regexp.Compile(rangePatternRegex)
+	if err != nil {
+		return fmt.Errorf("failed to compile range pattern: %w", err)
+	}
+	l.rangePattern = rangePattern
+
+	durationPrefixPattern, err := regexp.Compile(durationPrefixRegex)
+	if err != nil {
+		return fmt.Errorf("failed to compile duration prefix pattern: %w", err)
+	}
+	l.durationPrefixPattern = durationPrefixPattern
+
+	durationSuffixPattern, err := regexp.Compile(durationSuffixRegex)
+	if err != nil {
+		return fmt.Errorf("failed to compile duration suffix pattern: %w", err)
+	}
+	l.durationSuffixPattern = durationSuffixPattern
+
 	fullDatePattern, err := regexp.Compile(fullDatePatternRegex)
 	if err != nil {
 		return fmt.Errorf("failed to compile full date pattern: %w", err)
@@ -51,19 +100,25 @@ func (l *LogParser) Init() error {
 	}
 	l.dayMonthPattern = dayMonthPattern
 
-	flexPattern, err := regexp.Compile(flexPatternRegex)
+	flexPattern, err := regexp.Compile(fmt.Sprintf(flexPatternRegex, alternation(l.Vocabulary.Flex)))
 	if err != nil {
 		return fmt.Errorf("failed to compile flex pattern: %w", err)
 	}
 	l.flexPattern = flexPattern
 
-	targetPattern, err := regexp.Compile(targetPatternRegex)
+	flexDayPattern, err := regexp.Compile(fmt.Sprintf(flexDayPatternRegex, alternation(l.Vocabulary.FlexDay)))
+	if err != nil {
+		return fmt.Errorf("failed to compile flex day pattern: %w", err)
+	}
+	l.flexDayPattern = flexDayPattern
+
+	targetPattern, err := regexp.Compile(fmt.Sprintf(targetPatternRegex, alternation(l.Vocabulary.Target)))
 	if err != nil {
 		return fmt.Errorf("failed to compile target pattern: %w", err)
 	}
 	l.targetPattern = targetPattern
 
-	outputPattern, err := regexp.Compile(outputPatternRegex)
+	outputPattern, err := regexp.Compile(fmt.Sprintf(outputPatternRegex, alternation(l.Vocabulary.Output)))
 	if err != nil {
 		return fmt.Errorf("failed to compile format pattern: %w", err)
 	}
@@ -77,3 +132,25 @@ func (l *LogParser) Init() error {
 func (l *LogParser) addWarning(w string) {
 	l.warnings = append(l.warnings, w)
 }
+
+func (l *LogParser) validateVocabulary() error {
+	keywordSets := []struct {
+		name     string
+		keywords []string
+	}{
+		{"start", l.Vocabulary.Start},
+		{"stop", l.Vocabulary.Stop},
+		{"target", l.Vocabulary.Target},
+		{"flex", l.Vocabulary.Flex},
+		{"flex day", l.Vocabulary.FlexDay},
+		{"output", l.Vocabulary.Output},
+	}
+
+	for _, ks := range keywordSets {
+		if len(ks.keywords) == 0 {
+			return fmt.Errorf("no %s keywords configured", ks.name)
+		}
+	}
+
+	return nil
+}
@@ -124,3 +124,108 @@ func TestParseCategories(t *testing.T) {
 		t.Errorf("did not get day entry")
 	}
 }
+
+func TestResolveDialect(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		expected string
+	}{
+		{"", "work.txt", DefaultDialect},
+		{"", "work", DefaultDialect},
+		{"", "work.unknown", DefaultDialect},
+		{"Clocker", "work.org", DefaultDialect},
+		{"", "work.md", DefaultDialect},
+		{"markdown", "work.md", "markdown"},
+		{"", "2025-10.data", DefaultDialect},
+		{"timewarrior", "2025-10.data", "timewarrior"},
+	}
+
+	for _, te := range tests {
+		got, err := ResolveDialect(te.name, te.filePath)
+		if err != nil {
+			t.Errorf("ResolveDialect(%q, %q): %s", te.name, te.filePath, err.Error())
+			continue
+		}
+		if got != te.expected {
+			t.Errorf("ResolveDialect(%q, %q): expected %q, got %q", te.name, te.filePath, te.expected, got)
+		}
+	}
+
+	_, err := ResolveDialect("nonexistent", "work.txt")
+	if err == nil {
+		t.Errorf("expected an error for an unknown dialect")
+	}
+}
+
+func TestDialectFallback(t *testing.T) {
+	text := "-- Friday 17.10.2025\n08:00 - Start\n11:30 - Stop\n"
+
+	s, err := NewSubscriber("notes/work.org", "", nil)
+	if err != nil {
+		t.Fatalf("NewSubscriber: %s", err)
+	}
+
+	entries, err := s.parse(text)
+	if err != nil {
+		t.Fatalf("s.parse: %s", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("expected the default dialect to parse the file, got %d entries", len(entries))
+	}
+
+	// a configured dialect is used as it is
+	s, err = NewSubscriber("notes/work.org", "org", nil)
+	if err != nil {
+		t.Fatalf("NewSubscriber: %s", err)
+	}
+
+	entries, err = s.parse(text)
+	if err != nil {
+		t.Fatalf("s.parse: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no fallback for a configured dialect, got %d entries", len(entries))
+	}
+}
+
+func TestParseRanges(t *testing.T) {
+	testText := `
+	09:00-10:30 Meeting: sprint review
+	10:30 - 11:00 Support
+	23:30-00:15 Deploy
+	`
+	expectedActions := []string{"rangestart", "rangeend", "rangestart", "rangeend", "rangestart", "rangeend"}
+	expectedTimes := []string{"09:00", "10:30", "10:30", "11:00", "23:30", "00:15"}
+
+	lp := LogParser{}
+	err := lp.Init()
+	if err != nil {
+		t.Errorf("lp.Init: %s", err.Error())
+		return
+	}
+
+	entries := lp.Parse(testText)
+	if len(entries) != len(expectedActions) {
+		t.Errorf("entry length mismatch: expected %d, got %d", len(expectedActions), len(entries))
+		return
+	}
+
+	for i, entry := range entries {
+		if entry.Action != expectedActions[i] {
+			t.Errorf("wrong action for entry %d: expected %s, got %s", i, expectedActions[i], entry.Action)
+		}
+		if entry.Timestamp.Format("15:04") != expectedTimes[i] {
+			t.Errorf("wrong time for entry %d: expected %s, got %s", i, expectedTimes[i], entry.Timestamp.Format("15:04"))
+		}
+	}
+
+	if entries[0].Task != "Meeting: sprint review" {
+		t.Errorf("wrong task: expected %q, got %q", "Meeting: sprint review", entries[0].Task)
+	}
+
+	// ranges past midnight end on the following day
+	if !entries[5].Timestamp.After(*entries[4].Timestamp) {
+		t.Errorf("expected the range end to be after its start")
+	}
+}
@@ -13,14 +13,30 @@ import (
 )
 
 type Subscriber struct {
-	filePath string
-	lastRead time.Time
-	mu       sync.Mutex
-	receiver logentry.Receiver
+	filePath   string
+	dialect    string
+	fallback   bool // the dialect was picked by the file extension
+	vocabulary *Vocabulary
+	lastRead   time.Time
+	mu         sync.Mutex
+	receiver   logentry.Receiver
 }
 
-func NewSubscriber(filePath string) (*Subscriber, error) {
-	return &Subscriber{filePath: filePath}, nil
+// NewSubscriber creates a subscriber for the file. If dialect is empty, the
+// dialect is selected by the file extension, falling back to the default
+// dialect for files it finds nothing in.
+func NewSubscriber(filePath, dialect string, vocabulary *Vocabulary) (*Subscriber, error) {
+	dialectName, err := ResolveDialect(dialect, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("ResolveDialect: %w", err)
+	}
+
+	return &Subscriber{
+		filePath:   filePath,
+		dialect:    dialectName,
+		fallback:   dialect == "" && dialectName != DefaultDialect,
+		vocabulary: vocabulary,
+	}, nil
 }
 
 func (s *Subscriber) Subscribe(receiver logentry.Receiver) error {
@@ -81,18 +97,16 @@ func (s *Subscriber) reactToFileWrite(filepath string) error {
 	}
 	s.lastRead = time.Now()
 
-	lp := LogParser{}
-	err := lp.Init()
+	b, err := readLoop(filepath)
 	if err != nil {
-		return fmt.Errorf("lp.Init: %w", err)
+		return fmt.Errorf("readLoop: %w", err)
 	}
 
-	b, err := readLoop(filepath)
+	entries, err := s.parse(string(b))
 	if err != nil {
-		return fmt.Errorf("readLoop: %w", err)
+		return fmt.Errorf("s.parse: %w", err)
 	}
 
-	entries := lp.Parse(string(b))
 	err = s.receiver.Receive(entries)
 
 	if err != nil {
@@ -102,6 +116,28 @@ func (s *Subscriber) reactToFileWrite(filepath string) error {
 	return nil
 }
 
+// parse parses the text with the subscriber's dialect. A file that got its
+// dialect from the extension, but holds a log in the default format, is parsed
+// with the default dialect instead.
+func (s *Subscriber) parse(text string) ([]logentry.Entry, error) {
+	dialect, err := NewDialect(s.dialect, DialectOptions{Vocabulary: s.vocabulary})
+	if err != nil {
+		return nil, fmt.Errorf("NewDialect: %w", err)
+	}
+
+	entries := dialect.Parse(text)
+	if len(entries) > 0 || !s.fallback {
+		return entries, nil
+	}
+
+	dialect, err = NewDialect(DefaultDialect, DialectOptions{Vocabulary: s.vocabulary})
+	if err != nil {
+		return nil, fmt.Errorf("NewDialect: %w", err)
+	}
+
+	return dialect.Parse(text), nil
+}
+
 // readLoop tries to read the file a lot
 func readLoop(filepath string) ([]byte, error) {
 	for i := 0; i < 100; i++ {
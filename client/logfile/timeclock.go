@@ -0,0 +1,134 @@
+package logfile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func init() {
+	RegisterDialect("timeclock", []string{".timeclock"}, func(opts DialectOptions) (Dialect, error) {
+		tp := &TimeclockParser{}
+		err := tp.Init()
+		if err != nil {
+			return nil, fmt.Errorf("tp.Init: %w", err)
+		}
+		return tp, nil
+	})
+}
+
+// i 2025/10/17 08:15:00 dev:review  optional description  ; optional comment
+// o 2025/10/17 11:35:00
+const timeclockRegex = `^([iIoO])\s+(\d{4}[/-]\d{2}[/-]\d{2})\s+(\d{1,2}:\d{2}(?::\d{2})?)(?:\s+(.*))?$`
+
+// TimeclockParser implements the timeclock format of ledger and hledger. Each
+// clock-in line starts a clock on an account, and the next clock-out line
+// stops it.
+//
+// The first segment of the account is used as the category, and the rest of
+// the account and the description as the task. A comment after the
+// description becomes the note.
+type TimeclockParser struct {
+	linePattern *regexp.Regexp
+	warnings    []string
+}
+
+func (tp *TimeclockParser) Init() error {
+	compiled, err := regexp.Compile(timeclockRegex)
+	if err != nil {
+		return fmt.Errorf("failed to compile timeclock pattern: %w", err)
+	}
+	tp.linePattern = compiled
+	tp.warnings = []string{}
+	return nil
+}
+
+func (tp *TimeclockParser) Parse(text string) []logentry.Entry {
+	clocks := []clock{}
+	var open *clock
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		matches := tp.linePattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue // comments, and the rest of the ledger syntax
+		}
+
+		t, err := parseTimeclockTimestamp(matches[2], matches[3])
+		if err != nil {
+			tp.addWarningf("error parsing the timestamp on line %d: %s", i+1, err.Error())
+			continue
+		}
+
+		switch strings.ToLower(matches[1]) {
+		case "i":
+			if open != nil {
+				tp.addWarningf("clock-in on line %d while the clock from line %d is running", i+1, open.lineNumber)
+				clocks = append(clocks, *open)
+			}
+			task, note := timeclockTask(matches[4])
+			open = &clock{
+				start:      t,
+				task:       task,
+				note:       note,
+				lineNumber: i + 1,
+			}
+		case "o":
+			if open == nil {
+				tp.addWarningf("clock-out on line %d without a clock-in", i+1)
+				continue
+			}
+			open.end = t
+			clocks = append(clocks, *open)
+			open = nil
+		}
+	}
+
+	if open != nil {
+		clocks = append(clocks, *open)
+	}
+
+	return clockEntries(clocks)
+}
+
+// timeclockTask builds the "category: task" string from the account and the
+// description, which are separated by at least two spaces, and returns the
+// comment as the note. The tag that marks clocker's own exports is left out.
+func timeclockTask(rest string) (task, note string) {
+	rest, note, _ = strings.Cut(rest, ";")
+	note = strings.TrimSpace(note)
+	note = strings.TrimRight(strings.TrimSuffix(note, "clocker:"), ", ")
+
+	account, description, _ := strings.Cut(strings.TrimSpace(rest), "  ")
+	description = strings.TrimSpace(description)
+
+	category, subAccount, _ := strings.Cut(strings.TrimSpace(account), ":")
+
+	var parts []string
+	if subAccount != "" {
+		parts = append(parts, subAccount)
+	}
+	if description != "" {
+		parts = append(parts, description)
+	}
+
+	if len(parts) == 0 {
+		return category, note
+	}
+	return category + ": " + strings.Join(parts, " "), note
+}
+
+func parseTimeclockTimestamp(date, clockTime string) (time.Time, error) {
+	date = strings.ReplaceAll(date, "-", "/")
+	if strings.Count(clockTime, ":") == 1 {
+		clockTime += ":00"
+	}
+	return time.ParseInLocation("2006/01/02 15:04:05", date+" "+clockTime, time.Local)
+}
+
+func (tp *TimeclockParser) addWarningf(format string, v ...any) {
+	tp.warnings = append(tp.warnings, fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,79 @@
+package logfile
+
+import (
+	"testing"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func TestParseTimeclock(t *testing.T) {
+	testText := `; exported from hledger
+i 2025/10/17 08:15:00 dev:review  pull requests  ; backend
+o 2025/10/17 11:35:00
+i 2025-10-17 12:15 meeting  ; clocker:
+O 2025-10-17 13:00
+* a comment
+i 2025/10/18 23:30:00 support
+o 2025/10/19 00:30:00
+`
+
+	expectedActions := []string{
+		logentry.ActionSetDay,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionSetDay,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionSetDay,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+	}
+	expectedTasks := []string{"", "dev: review pull requests", "", "meeting", "", "", "support", "", "", "support", ""}
+	expectedTimes := []string{"", "08:15", "11:35", "12:15", "13:00", "", "23:30", "00:00", "", "00:00", "00:30"}
+
+	tp := TimeclockParser{}
+	err := tp.Init()
+	if err != nil {
+		t.Errorf("tp.Init: %s", err.Error())
+		return
+	}
+
+	entries := tp.Parse(testText)
+	if len(entries) != len(expectedActions) {
+		t.Errorf("entry length mismatch: expected %d, got %d", len(expectedActions), len(entries))
+		return
+	}
+
+	for i, entry := range entries {
+		if entry.Action != expectedActions[i] {
+			t.Errorf("wrong action for entry %d: expected %q, got %q", i, expectedActions[i], entry.Action)
+		}
+
+		if entry.Task != expectedTasks[i] {
+			t.Errorf("wrong task for entry %d: expected %q, got %q", i, expectedTasks[i], entry.Task)
+		}
+
+		if entry.Timestamp != nil && entry.Timestamp.Format("15:04") != expectedTimes[i] {
+			t.Errorf("wrong time for entry %d: expected %s, got %s", i, expectedTimes[i], entry.Timestamp.Format("15:04"))
+		}
+	}
+
+	if entries[1].Note != "backend" {
+		t.Errorf("expected the comment as the note, got %q", entries[1].Note)
+	}
+
+	if entries[3].Note != "" {
+		t.Errorf("expected the export tag to be left out of the note, got %q", entries[3].Note)
+	}
+
+	if len(tp.warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", tp.warnings)
+	}
+
+	name, err := ResolveDialect("", "ledger/2025.timeclock")
+	if err != nil || name != "timeclock" {
+		t.Errorf("expected .timeclock files to resolve to the timeclock dialect, got %q (%v)", name, err)
+	}
+}
@@ -0,0 +1,141 @@
+package logfile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func init() {
+	// .data is too generic an extension to claim, so the dialect has to be
+	// selected explicitly.
+	RegisterDialect("timewarrior", nil, func(opts DialectOptions) (Dialect, error) {
+		return &TimewarriorParser{warnings: []string{}}, nil
+	})
+}
+
+// timewarriorLayout is the format of the timestamps in Timewarrior's data
+// files, which are always in UTC.
+const timewarriorLayout = "20060102T150405Z"
+
+// TimewarriorParser implements the data files of Timewarrior, such as
+// 2025-10.data:
+//
+//	inc 20251017T061500Z - 20251017T093500Z # dev "code review" # "annotation"
+//
+// The first tag is used as the category, and the rest of the tags as the task.
+// The annotation becomes the note. The tag that marks clocker's own exports is
+// left out.
+type TimewarriorParser struct {
+	warnings []string
+}
+
+func (tw *TimewarriorParser) Parse(text string) []logentry.Entry {
+	clocks := []clock{}
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "inc ") {
+			continue
+		}
+
+		c, err := parseTimewarriorLine(line)
+		if err != nil {
+			tw.warnings = append(tw.warnings, fmt.Sprintf("error parsing line %d: %s", i+1, err.Error()))
+			continue
+		}
+		c.lineNumber = i + 1
+		clocks = append(clocks, c)
+	}
+
+	return clockEntries(clocks)
+}
+
+// parseTimewarriorLine parses an interval line of a Timewarrior data file.
+func parseTimewarriorLine(line string) (clock, error) {
+	c := clock{}
+
+	interval, rest, _ := strings.Cut(strings.TrimPrefix(line, "inc "), "#")
+	times := strings.Fields(interval)
+	if len(times) != 1 && !(len(times) == 3 && times[1] == "-") {
+		return c, fmt.Errorf("invalid interval %q", strings.TrimSpace(interval))
+	}
+
+	var err error
+	c.start, err = time.Parse(timewarriorLayout, times[0])
+	if err != nil {
+		return c, fmt.Errorf("invalid start: %w", err)
+	}
+	c.start = c.start.In(time.Local)
+
+	if len(times) == 3 {
+		c.end, err = time.Parse(timewarriorLayout, times[2])
+		if err != nil {
+			return c, fmt.Errorf("invalid end: %w", err)
+		}
+		c.end = c.end.In(time.Local)
+	}
+
+	tags, annotation, err := splitTimewarriorTags(rest)
+	if err != nil {
+		return c, err
+	}
+
+	if len(tags) > 1 && tags[len(tags)-1] == "clocker" {
+		tags = tags[:len(tags)-1]
+	}
+
+	if len(tags) > 0 {
+		c.task = tags[0]
+		if len(tags) > 1 {
+			c.task += ": " + strings.Join(tags[1:], " ")
+		}
+	}
+	c.note = annotation
+
+	return c, nil
+}
+
+// splitTimewarriorTags reads the tags, and the annotation after a second "#".
+// Tags and annotations with spaces are quoted.
+func splitTimewarriorTags(s string) (tags []string, annotation string, err error) {
+	inAnnotation := false
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		var token string
+		if s[0] == '"' {
+			end := 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				return nil, "", fmt.Errorf("unterminated quote in %q", s)
+			}
+
+			token, err = strconv.Unquote(s[:end+1])
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid quoted tag %s: %w", s[:end+1], err)
+			}
+			s = s[end+1:]
+		} else {
+			token, s, _ = strings.Cut(s, " ")
+			if token == "#" {
+				inAnnotation = true
+				continue
+			}
+		}
+
+		if inAnnotation {
+			annotation = strings.TrimSpace(annotation + " " + token)
+		} else {
+			tags = append(tags, token)
+		}
+	}
+
+	return tags, annotation, nil
+}
@@ -0,0 +1,61 @@
+package logfile
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/logentry"
+)
+
+func TestParseTimewarrior(t *testing.T) {
+	// the data files are in UTC, and the entries in local time
+	utc := func(hour, min int) string {
+		return time.Date(2025, time.October, 17, hour, min, 0, 0, time.Local).UTC().Format(timewarriorLayout)
+	}
+
+	testText := fmt.Sprintf(`inc %s - %s # dev "code review" # "the \"new\" API"
+inc %s - %s # meeting clocker
+inc %s
+`, utc(8, 15), utc(11, 35), utc(12, 15), utc(13, 0), utc(14, 0))
+
+	expectedActions := []string{
+		logentry.ActionSetDay,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionStartTask,
+		logentry.ActionClockOut,
+		logentry.ActionStartTask,
+	}
+	expectedTasks := []string{"", "dev: code review", "", "meeting", "", ""}
+	expectedTimes := []string{"", "08:15", "11:35", "12:15", "13:00", "14:00"}
+
+	tw := TimewarriorParser{}
+	entries := tw.Parse(testText)
+	if len(entries) != len(expectedActions) {
+		t.Errorf("entry length mismatch: expected %d, got %d", len(expectedActions), len(entries))
+		return
+	}
+
+	for i, entry := range entries {
+		if entry.Action != expectedActions[i] {
+			t.Errorf("wrong action for entry %d: expected %q, got %q", i, expectedActions[i], entry.Action)
+		}
+
+		if entry.Task != expectedTasks[i] {
+			t.Errorf("wrong task for entry %d: expected %q, got %q", i, expectedTasks[i], entry.Task)
+		}
+
+		if entry.Timestamp != nil && entry.Timestamp.Format("15:04") != expectedTimes[i] {
+			t.Errorf("wrong time for entry %d: expected %s, got %s", i, expectedTimes[i], entry.Timestamp.Format("15:04"))
+		}
+	}
+
+	if entries[1].Note != `the "new" API` {
+		t.Errorf("expected the annotation as the note, got %q", entries[1].Note)
+	}
+
+	if len(tw.warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", tw.warnings)
+	}
+}
@@ -0,0 +1,144 @@
+package logfile
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sporadisk/clocker/config"
+)
+
+// Vocabulary holds the keywords recognized by the log parser.
+type Vocabulary struct {
+	Start   []string // clock-in keywords, as in "08:00 - Start"
+	Stop    []string // clock-out keywords, as in "11:30 - Break"
+	Target  []string // target prefixes, as in "Workday: 8h"
+	Flex    []string // flex prefixes, as in "Flex: 1h"
+	FlexDay []string // flex day directives, as in "Flex day"
+	Output  []string // output format prefixes, as in "Format: m"
+
+	// Connectives are words that make a trailing duration part of the text,
+	// as in "Note: call back in 2h", rather than the duration of the task.
+	Connectives []string
+}
+
+const DefaultLanguage = "en"
+
+// languagePacks are the built-in keyword sets, selectable through the
+// log.languages config setting.
+var languagePacks = map[string]Vocabulary{
+	"en": {
+		Start:   []string{"start", "on", "back", "resume"},
+		Stop:    []string{"stop", "break", "pause", "done", "off", "end"},
+		Target:  []string{"target", "full day", "workday"},
+		Flex:    []string{"flex"},
+		FlexDay: []string{"flex day", "flexday"},
+		Output:  []string{"output", "format"},
+
+		Connectives: []string{"after", "in", "for", "within", "every", "by", "ago", "about", "over", "under"},
+	},
+	"nb": {
+		Start:   []string{"start", "inn", "tilbake", "fortsett"},
+		Stop:    []string{"stopp", "pause", "lunsj", "ferdig", "slutt", "ut"},
+		Target:  []string{"mål", "arbeidsdag", "full dag"},
+		Flex:    []string{"fleks", "flex"},
+		FlexDay: []string{"fleksidag", "fleksdag", "avspasering"},
+		Output:  []string{"visning", "format"},
+
+		Connectives: []string{"etter", "om", "i", "på", "for", "innen", "hver", "siden", "ca", "over", "under"},
+	},
+}
+
+// LoadVocabulary merges the configured language packs and custom keywords
+// into a single vocabulary. A nil config yields the default language pack.
+func LoadVocabulary(conf *config.LogConfig) (*Vocabulary, error) {
+	languages := []string{DefaultLanguage}
+	if conf != nil && len(conf.Languages) > 0 {
+		languages = conf.Languages
+	}
+
+	vocab := &Vocabulary{}
+	for _, lang := range languages {
+		pack, ok := languagePacks[strings.ToLower(strings.TrimSpace(lang))]
+		if !ok {
+			return nil, fmt.Errorf("unknown keyword language %q - Available languages: %s", lang, strings.Join(Languages(), ", "))
+		}
+		vocab.add(pack)
+	}
+
+	if conf != nil && conf.Keywords != nil {
+		vocab.add(Vocabulary{
+			Start:   conf.Keywords.Start,
+			Stop:    conf.Keywords.Stop,
+			Target:  conf.Keywords.Target,
+			Flex:    conf.Keywords.Flex,
+			FlexDay: conf.Keywords.FlexDay,
+			Output:  conf.Keywords.Output,
+
+			Connectives: conf.Keywords.Connectives,
+		})
+	}
+
+	return vocab, nil
+}
+
+// Languages returns the names of the built-in language packs.
+func Languages() []string {
+	var langs []string
+	for lang := range languagePacks {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+func (v *Vocabulary) add(other Vocabulary) {
+	v.Start = appendKeywords(v.Start, other.Start)
+	v.Stop = appendKeywords(v.Stop, other.Stop)
+	v.Target = appendKeywords(v.Target, other.Target)
+	v.Flex = appendKeywords(v.Flex, other.Flex)
+	v.FlexDay = appendKeywords(v.FlexDay, other.FlexDay)
+	v.Output = appendKeywords(v.Output, other.Output)
+	v.Connectives = appendKeywords(v.Connectives, other.Connectives)
+}
+
+// appendKeywords adds keywords that are not already in the list.
+func appendKeywords(list []string, keywords []string) []string {
+	for _, kw := range keywords {
+		kw = strings.ToLower(strings.TrimSpace(kw))
+		if kw == "" {
+			continue
+		}
+
+		exists := false
+		for _, existing := range list {
+			if existing == kw {
+				exists = true
+				break
+			}
+		}
+
+		if !exists {
+			list = append(list, kw)
+		}
+	}
+	return list
+}
+
+// alternation builds a regex alternation group body from a keyword list. The
+// longest keywords are listed first, so that they take precedence over their
+// prefixes.
+func alternation(keywords []string) string {
+	sorted := make([]string, len(keywords))
+	copy(sorted, keywords)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	quoted := make([]string, len(sorted))
+	for i, kw := range sorted {
+		quoted[i] = strings.ReplaceAll(regexp.QuoteMeta(kw), " ", `\s+`)
+	}
+	return strings.Join(quoted, "|")
+}
@@ -0,0 +1,200 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+td.num { text-align: right; }
+.warning { color: #a15c00; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Days}}
+<h2>{{.Heading}}</h2>
+{{if .Error}}<p class="error">{{t "Could not parse input"}}: {{.Error}}</p>{{else}}
+{{if .Categories}}<table>
+<tr><th>{{t "Category"}}</th><th>{{t "Time"}}</th></tr>
+{{range .Categories}}<tr><td>{{.Name}}</td><td class="num">{{.Time}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Rates}}<table>
+<tr><th>{{t "Rate"}}</th><th>{{t "Multiplier"}}</th><th>{{t "Time"}}</th></tr>
+{{range .Rates}}<tr><td>{{.Name}}</td><td class="num">{{.Multiplier}}</td><td class="num">{{.Time}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Events}}<table>
+<tr><th>{{t "Start"}}</th><th>{{t "End"}}</th><th>{{t "Duration"}}</th><th>{{t "Category"}}</th><th>{{t "Task"}}</th></tr>
+{{range .Events}}<tr><td>{{.Start}}</td><td>{{.End}}</td><td class="num">{{.Duration}}</td><td>{{.Category}}</td><td>{{.Task}}</td></tr>
+{{end}}</table>{{end}}
+<ul>
+<li>{{t "Worked"}}: {{.Worked}}</li>
+<li>{{t "Target"}}: {{.Target}}</li>
+<li>{{t "Balance"}}: {{.Balance}}</li>
+{{if .Breaks}}<li>{{t "Breaks"}}: {{t "%s (%s paid)" .Breaks .PaidBreaks}}</li>
+{{end}}{{if .FlexUsed}}<li>{{t "Flex used"}}: {{.FlexUsed}}</li>
+{{end}}{{if .FlexEarned}}<li>{{t "Flex earned"}}: {{.FlexEarned}}</li>
+{{end}}{{if .Weighted}}<li>{{t "Weighted"}}: {{.Weighted}}</li>
+{{end}}</ul>
+{{if .Warnings}}<ul class="warning">
+{{range .Warnings}}<li>{{.}}</li>
+{{end}}</ul>{{end}}
+{{end}}
+{{end}}
+{{if .Total}}<h2>{{t "Total"}}</h2>
+<ul>
+<li>{{t "Worked"}}: {{.Total.Worked}}</li>
+<li>{{t "Target"}}: {{.Total.Target}}</li>
+<li>{{t "Balance"}}: {{.Total.Balance}}</li>
+</ul>{{end}}
+</body>
+</html>
+`
+
+type htmlReport struct {
+	Title string
+	Days  []htmlDay
+	Total *htmlTotal
+}
+
+type htmlDay struct {
+	Heading    string
+	Error      string
+	Categories []htmlCategory
+	Events     []htmlEvent
+	Worked     string
+	Target     string
+	Balance    string
+	Breaks     string
+	PaidBreaks string
+	FlexUsed   string
+	FlexEarned string
+	Rates      []htmlRate
+	Weighted   string
+	Warnings   []string
+}
+
+type htmlRate struct {
+	Name       string
+	Multiplier string
+	Time       string
+}
+
+type htmlCategory struct {
+	Name string
+	Time string
+}
+
+type htmlEvent struct {
+	Start    string
+	End      string
+	Duration string
+	Category string
+	Task     string
+}
+
+type htmlTotal struct {
+	Worked  string
+	Target  string
+	Balance string
+}
+
+// HTML renders the report as a self-contained HTML document. The labels are
+// translated with the t function.
+func (r *Report) HTML() (string, error) {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{"t": r.Locale.Sprintf}).Parse(htmlTemplate)
+	if err != nil {
+		return "", fmt.Errorf("template.Parse: %w", err)
+	}
+
+	var sb strings.Builder
+	err = tmpl.Execute(&sb, r.htmlReport())
+	if err != nil {
+		return "", fmt.Errorf("tmpl.Execute: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
+func (r *Report) htmlReport() htmlReport {
+	hr := htmlReport{
+		Title: r.title(),
+	}
+
+	for _, day := range r.Days {
+		sum := day.Summary
+		hd := htmlDay{
+			Heading: r.Locale.Date(dayDate(day)),
+		}
+
+		if !sum.Valid {
+			hd.Error = sum.ValidationMsg
+			hr.Days = append(hr.Days, hd)
+			continue
+		}
+
+		for _, cat := range sum.Categories {
+			hd.Categories = append(hd.Categories, htmlCategory{
+				Name: cat.Name,
+				Time: r.duration(cat.TimeWorked),
+			})
+		}
+
+		for _, e := range day.Events {
+			hd.Events = append(hd.Events, htmlEvent{
+				Start:    eventTime(e.Start),
+				End:      eventTime(e.End),
+				Duration: r.duration(e.Duration()),
+				Category: e.Category,
+				Task:     e.Task,
+			})
+		}
+
+		hd.Worked = r.duration(sum.TimeWorked)
+		hd.Target = r.duration(sum.Target)
+		hd.Balance = r.balance(sum.TimeWorked, sum.Target)
+		hd.Warnings = sum.Warnings
+		if sum.BreakTime > 0 {
+			hd.Breaks = r.duration(sum.BreakTime)
+			hd.PaidBreaks = r.duration(sum.PaidBreakTime)
+		}
+		if sum.FlexUsed > 0 {
+			hd.FlexUsed = r.duration(sum.FlexUsed)
+		}
+		if sum.FlexEarned > 0 {
+			hd.FlexEarned = r.duration(sum.FlexEarned)
+		}
+		for _, rc := range sum.RateClasses {
+			hd.Rates = append(hd.Rates, htmlRate{
+				Name:       rc.Name,
+				Multiplier: fmt.Sprintf("%gx", rc.Multiplier),
+				Time:       r.duration(rc.TimeWorked),
+			})
+		}
+		if len(sum.RateClasses) > 0 {
+			hd.Weighted = r.duration(sum.WeightedTime)
+		}
+		hr.Days = append(hr.Days, hd)
+	}
+
+	if len(r.Days) > 1 {
+		worked, target := r.totals()
+		hr.Total = &htmlTotal{
+			Worked:  r.duration(worked),
+			Target:  r.duration(target),
+			Balance: r.balance(worked, target),
+		}
+	}
+
+	return hr
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders the report as a Markdown document.
+func (r *Report) Markdown() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n", mdEscape(r.title()))
+
+	for _, day := range r.Days {
+		sum := day.Summary
+		fmt.Fprintf(&sb, "## %s\n\n", mdEscape(r.Locale.Date(dayDate(day))))
+
+		if !sum.Valid {
+			fmt.Fprintf(&sb, "%s: %s\n\n", r.Locale.Sprintf("Could not parse input"), mdEscape(sum.ValidationMsg))
+			continue
+		}
+
+		if len(sum.Categories) > 0 {
+			fmt.Fprintf(&sb, "| %s | %s |\n", r.Locale.Sprintf("Category"), r.Locale.Sprintf("Time"))
+			sb.WriteString("| --- | ---: |\n")
+			for _, cat := range sum.Categories {
+				fmt.Fprintf(&sb, "| %s | %s |\n", mdEscape(cat.Name), r.duration(cat.TimeWorked))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(sum.RateClasses) > 0 {
+			fmt.Fprintf(&sb, "| %s | %s | %s |\n", r.Locale.Sprintf("Rate"), r.Locale.Sprintf("Multiplier"), r.Locale.Sprintf("Time"))
+			sb.WriteString("| --- | ---: | ---: |\n")
+			for _, rc := range sum.RateClasses {
+				fmt.Fprintf(&sb, "| %s | %gx | %s |\n", mdEscape(rc.Name), rc.Multiplier, r.duration(rc.TimeWorked))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(day.Events) > 0 {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n", r.Locale.Sprintf("Start"), r.Locale.Sprintf("End"),
+				r.Locale.Sprintf("Duration"), r.Locale.Sprintf("Category"), r.Locale.Sprintf("Task"))
+			sb.WriteString("| --- | --- | ---: | --- | --- |\n")
+			for _, e := range day.Events {
+				fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n",
+					eventTime(e.Start),
+					eventTime(e.End),
+					r.duration(e.Duration()),
+					mdEscape(e.Category),
+					mdEscape(e.Task),
+				)
+			}
+			sb.WriteString("\n")
+		}
+
+		mdItem(&sb, r.Locale.Sprintf("Worked"), r.duration(sum.TimeWorked))
+		mdItem(&sb, r.Locale.Sprintf("Target"), r.duration(sum.Target))
+		mdItem(&sb, r.Locale.Sprintf("Balance"), r.balance(sum.TimeWorked, sum.Target))
+		if sum.BreakTime > 0 {
+			mdItem(&sb, r.Locale.Sprintf("Breaks"), r.Locale.Sprintf("%s (%s paid)", r.duration(sum.BreakTime), r.duration(sum.PaidBreakTime)))
+		}
+		if sum.FlexUsed > 0 {
+			mdItem(&sb, r.Locale.Sprintf("Flex used"), r.duration(sum.FlexUsed))
+		}
+		if sum.FlexEarned > 0 {
+			mdItem(&sb, r.Locale.Sprintf("Flex earned"), r.duration(sum.FlexEarned))
+		}
+		if len(sum.RateClasses) > 0 {
+			mdItem(&sb, r.Locale.Sprintf("Weighted"), r.duration(sum.WeightedTime))
+		}
+		sb.WriteString("\n")
+
+		if len(sum.Warnings) > 0 {
+			fmt.Fprintf(&sb, "%s:\n\n", r.Locale.Sprintf("Warnings"))
+			for _, w := range sum.Warnings {
+				fmt.Fprintf(&sb, "- %s\n", mdEscape(w))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(r.Days) > 1 {
+		worked, target := r.totals()
+		fmt.Fprintf(&sb, "## %s\n\n", r.Locale.Sprintf("Total"))
+		mdItem(&sb, r.Locale.Sprintf("Worked"), r.duration(worked))
+		mdItem(&sb, r.Locale.Sprintf("Target"), r.duration(target))
+		mdItem(&sb, r.Locale.Sprintf("Balance"), r.balance(worked, target))
+	}
+
+	return sb.String()
+}
+
+func mdItem(sb *strings.Builder, label, value string) {
+	fmt.Fprintf(sb, "- %s: %s\n", label, value)
+}
+
+// mdEscape escapes characters that would break table cells or inline
+// formatting.
+func mdEscape(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		"|", `\|`,
+		"*", `\*`,
+		"_", `\_`,
+		"`", "\\`",
+		"\n", " ",
+	)
+	return replacer.Replace(s)
+}
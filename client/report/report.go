@@ -0,0 +1,122 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/locale"
+	"github.com/sporadisk/clocker/parameter"
+	"github.com/sporadisk/clocker/summary"
+)
+
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Day holds the calculator output for a single log day.
+type Day struct {
+	Summary summary.Summary
+	Events  []*event.Event
+}
+
+// Report renders one or more days as a self-contained document.
+type Report struct {
+	Title      string
+	Days       []Day
+	TimeFormat string
+	Locale     *locale.Locale // nil for English
+}
+
+// Render renders the report in the specified format (markdown or html).
+func (r *Report) Render(reportFormat string) (string, error) {
+	f, err := parameter.Validate(reportFormat, []string{FormatMarkdown, FormatHTML, "md"})
+	if err != nil {
+		return "", fmt.Errorf("invalid report format: %w", err)
+	}
+
+	switch f {
+	case FormatHTML:
+		return r.HTML()
+	default:
+		return r.Markdown(), nil
+	}
+}
+
+// Extension returns the file extension used for the specified format.
+func Extension(reportFormat string) string {
+	if parameter.Clean(reportFormat) == FormatHTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+// title returns the report title, defaulting to the date range covered.
+func (r *Report) title() string {
+	if r.Title != "" {
+		return r.Title
+	}
+
+	first, last := r.dateRange()
+	if first.Equal(last) {
+		return r.Locale.Sprintf("Time report %s", r.Locale.Date(first))
+	}
+	return r.Locale.Sprintf("Time report %s - %s", r.Locale.Date(first), r.Locale.Date(last))
+}
+
+func (r *Report) dateRange() (first, last time.Time) {
+	for i, d := range r.Days {
+		date := dayDate(d)
+		if i == 0 || date.Before(first) {
+			first = date
+		}
+		if i == 0 || date.After(last) {
+			last = date
+		}
+	}
+	return first, last
+}
+
+// dayDate returns the date of the log day, defaulting to today.
+func dayDate(d Day) time.Time {
+	if d.Summary.Date == nil {
+		y, m, day := time.Now().Date()
+		return time.Date(y, m, day, 0, 0, 0, 0, time.Local)
+	}
+	sd := d.Summary.Date
+	return time.Date(sd.Year, time.Month(sd.Month), sd.Day, 0, 0, 0, 0, time.Local)
+}
+
+func (r *Report) duration(d time.Duration) string {
+	s := format.Duration(d, r.TimeFormat)
+	if s == "" {
+		return "0m"
+	}
+	return s
+}
+
+// totals sums the worked time and target across all days in the report.
+func (r *Report) totals() (worked, target time.Duration) {
+	for _, d := range r.Days {
+		worked += d.Summary.TimeWorked
+		target += d.Summary.Target
+	}
+	return worked, target
+}
+
+// balance describes the difference between worked time and target.
+func (r *Report) balance(worked, target time.Duration) string {
+	if worked >= target {
+		return "+" + r.duration(worked-target)
+	}
+	return "-" + r.duration(target-worked)
+}
+
+func eventTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return format.Timestamp(t)
+}
@@ -0,0 +1,101 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/locale"
+	"github.com/sporadisk/clocker/summary"
+)
+
+func testDays() []Day {
+	start := time.Date(2025, 10, 17, 8, 15, 0, 0, time.Local)
+	end := time.Date(2025, 10, 17, 11, 35, 0, 0, time.Local)
+
+	return []Day{
+		{
+			Summary: summary.Summary{
+				Valid:      true,
+				TimeWorked: 200 * time.Minute,
+				Target:     450 * time.Minute,
+				Categories: []summary.ResultCategory{
+					{Name: "dev", TimeWorked: 200 * time.Minute},
+				},
+				Date:     &summary.Date{DayName: "friday", Day: 17, Month: 10, Year: 2025},
+				Warnings: []string{"short <day>"},
+			},
+			Events: []*event.Event{
+				{Start: start, End: end, Hours: 3, Minutes: 20, Category: "dev", Task: "review | merge"},
+			},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	r := &Report{Days: testDays()}
+	md := r.Markdown()
+
+	expected := []string{
+		"# Time report Friday 17.10.2025",
+		"## Friday 17.10.2025",
+		"| dev | 3h 20m |",
+		"| 08:15 | 11:35 | 3h 20m | dev | review \\| merge |",
+		"- Target: 7h 30m",
+		"- Balance: -4h 10m",
+		"- short <day>",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(md, e) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", e, md)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	r := &Report{Days: testDays()}
+	html, err := r.Render("html")
+	if err != nil {
+		t.Errorf("r.Render: %s", err.Error())
+		return
+	}
+
+	expected := []string{
+		"<h2>Friday 17.10.2025</h2>",
+		"<td>review | merge</td>",
+		"<li>short &lt;day&gt;</li>",
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(html, e) {
+			t.Errorf("expected html to contain %q, got:\n%s", e, html)
+		}
+	}
+}
+
+func TestLocalizedReport(t *testing.T) {
+	loc, err := locale.Load(locale.Norwegian)
+	if err != nil {
+		t.Fatalf("locale.Load: %s", err)
+	}
+
+	r := &Report{Days: testDays(), Locale: loc}
+	md := r.Markdown()
+	for _, e := range []string{"# Timerapport fredag 17.10.2025", "## fredag 17.10.2025", "| Kategori | Tid |", "- Arbeidet: 3h 20m", "Advarsler:"} {
+		if !strings.Contains(md, e) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", e, md)
+		}
+	}
+
+	html, err := r.HTML()
+	if err != nil {
+		t.Fatalf("r.HTML: %s", err)
+	}
+	for _, e := range []string{"<h2>fredag 17.10.2025</h2>", "<th>Oppgave</th>", "<li>Saldo: -4h 10m</li>"} {
+		if !strings.Contains(html, e) {
+			t.Errorf("expected html to contain %q, got:\n%s", e, html)
+		}
+	}
+}
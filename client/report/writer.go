@@ -0,0 +1,77 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/locale"
+)
+
+// Writer renders reports and writes them to files in a directory, named after
+// the date range they cover.
+type Writer struct {
+	Dir        string
+	Format     string
+	TimeFormat string
+	Locale     *locale.Locale
+}
+
+func (w *Writer) Init() error {
+	if w.Format == "" {
+		w.Format = FormatMarkdown
+	}
+
+	if w.TimeFormat == "" {
+		w.TimeFormat = format.TimeHM
+	}
+
+	if w.Dir == "" {
+		w.Dir = "."
+	}
+
+	finfo, err := os.Stat(w.Dir)
+	if err != nil {
+		return fmt.Errorf("os.Stat: %w", err)
+	}
+
+	if !finfo.IsDir() {
+		return fmt.Errorf("report path %s is not a directory", w.Dir)
+	}
+
+	return nil
+}
+
+// Write renders the days as a single report, and returns the path of the
+// written file.
+func (w *Writer) Write(days []Day) (string, error) {
+	if len(days) == 0 {
+		return "", fmt.Errorf("no days to report")
+	}
+
+	r := &Report{
+		Days:       days,
+		TimeFormat: w.TimeFormat,
+		Locale:     w.Locale,
+	}
+
+	content, err := r.Render(w.Format)
+	if err != nil {
+		return "", fmt.Errorf("r.Render: %w", err)
+	}
+
+	first, last := r.dateRange()
+	name := "clocker-" + first.Format("2006-01-02")
+	if !first.Equal(last) {
+		name += "_" + last.Format("2006-01-02")
+	}
+
+	path := filepath.Join(w.Dir, name+Extension(w.Format))
+	err = os.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		return "", fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return path, nil
+}
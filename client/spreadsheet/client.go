@@ -0,0 +1,100 @@
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+type sheetFile interface {
+	read(path string) ([][]string, error)
+	write(path string, records [][]string) error
+}
+
+// Client exports events to a spreadsheet file, with a header row and one row
+// per event or per day. Exporting a day again replaces its rows.
+type Client struct {
+	// Configuration
+	Path    string
+	Format  string
+	Mode    string
+	Rounded bool // add a column with the rounded duration
+
+	// State
+	file    sheetFile
+	columns []string
+}
+
+func (c *Client) Init() error {
+	if c.Mode == "" {
+		c.Mode = ModeEvents
+	}
+
+	switch c.Mode {
+	case ModeEvents, ModeDays:
+	default:
+		return fmt.Errorf("unknown mode %q - Use %s or %s", c.Mode, ModeEvents, ModeDays)
+	}
+
+	c.columns = columns(c.Mode, c.Rounded)
+
+	switch c.Format {
+	case FormatCSV:
+		c.file = csvFile{}
+	case FormatXLSX:
+		numeric := make([]bool, len(c.columns))
+		for i, col := range c.columns {
+			numeric[i] = slices.Contains(numericColumns, col)
+		}
+		c.file = xlsxFile{numeric: numeric}
+	default:
+		return fmt.Errorf("unknown format %q", c.Format)
+	}
+
+	return nil
+}
+
+// Export writes the rows of the events to the file, replacing the rows of the
+// same days.
+func (c *Client) Export(events []*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	existing, err := c.file.read(c.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading %s: %w", c.Path, err)
+	}
+
+	if len(existing) > 0 {
+		if !slices.Equal(existing[0], c.columns) {
+			return fmt.Errorf("the columns of %s don't match the configured columns %v - Use a new file", c.Path, c.columns)
+		}
+		existing = existing[1:]
+	}
+
+	records := [][]string{c.columns}
+	records = append(records, replaceDays(existing, rows(events, c.Mode, c.columns))...)
+
+	err = os.MkdirAll(filepath.Dir(c.Path), 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	err = c.file.write(c.Path, records)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", c.Path, err)
+	}
+
+	fmt.Printf("Wrote %d rows to %s.\n", len(records)-1, c.Path)
+	return nil
+}
@@ -0,0 +1,42 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+)
+
+// csvFile reads and writes sheets as comma-separated values.
+type csvFile struct{}
+
+func (csvFile) read(path string) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("csv.ReadAll: %w", err)
+	}
+	return records, nil
+}
+
+func (csvFile) write(path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("os.Create: %w", err)
+	}
+
+	w := csv.NewWriter(f)
+	err = w.WriteAll(records)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("csv.WriteAll: %w", err)
+	}
+
+	return f.Close()
+}
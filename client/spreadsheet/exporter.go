@@ -0,0 +1,57 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		event.RegisterExporter(event.ExporterSpec{
+			Name:        format,
+			Description: fmt.Sprintf("Writes events, or daily totals with mode: days, to a %s file", strings.ToUpper(format)),
+			Required:    []string{"file"},
+			Optional:    []string{"mode", "rounded"},
+			Factory:     factory(format),
+		})
+	}
+}
+
+func factory(format string) event.ExporterFactory {
+	return func(params map[string]string) (event.Exporter, error) {
+		return NewExporter(format, params)
+	}
+}
+
+// NewExporter creates a spreadsheet client for the format from exporter
+// params.
+func NewExporter(format string, params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "file")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		Path:   p["file"],
+		Format: format,
+		Mode:   strings.ToLower(params["mode"]),
+	}
+
+	rounded, ok := params["rounded"]
+	if ok {
+		client.Rounded, err = strconv.ParseBool(rounded)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse rounded as bool: %w", err)
+		}
+	}
+
+	err = client.Init()
+	if err != nil {
+		return nil, fmt.Errorf("spreadsheet.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,148 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+const (
+	ModeEvents = "events" // one row per event
+	ModeDays   = "days"   // one row per day, with the total
+)
+
+// The columns are always written in this order, so that formulas and imports
+// that refer to them keep working.
+var (
+	eventColumns = []string{"date", "start", "end", "hours", "minutes", "category", "task", "note"}
+	dayColumns   = []string{"date", "hours", "minutes"}
+	roundedCol   = "rounded"
+)
+
+// numericColumns are written as numbers rather than text, where the format
+// makes a difference.
+var numericColumns = []string{"hours", "minutes", roundedCol}
+
+func columns(mode string, rounded bool) []string {
+	cols := slices.Clone(eventColumns)
+	if mode == ModeDays {
+		cols = slices.Clone(dayColumns)
+	}
+
+	if rounded {
+		cols = append(cols, roundedCol)
+	}
+	return cols
+}
+
+// rawDuration returns the duration of the event before rounding.
+func rawDuration(e *event.Event) time.Duration {
+	if e.Raw > 0 {
+		return e.Raw
+	}
+	return e.Duration()
+}
+
+func eventDate(e *event.Event) string {
+	return fmt.Sprintf("%04d-%02d-%02d", e.Date.Year, e.Date.Month, e.Date.Day)
+}
+
+func clockTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("15:04")
+}
+
+func hours(d time.Duration) string {
+	return strconv.FormatFloat(d.Hours(), 'f', 2, 64)
+}
+
+func minutes(d time.Duration) string {
+	return strconv.Itoa(int(d.Minutes()))
+}
+
+// rows renders the events in the columns.
+func rows(events []*event.Event, mode string, cols []string) [][]string {
+	if mode == ModeDays {
+		return dayRows(events, cols)
+	}
+
+	var result [][]string
+	for _, e := range events {
+		values := map[string]string{
+			"date":     eventDate(e),
+			"start":    clockTime(e.Start),
+			"end":      clockTime(e.End),
+			"hours":    hours(rawDuration(e)),
+			"minutes":  minutes(rawDuration(e)),
+			"category": e.Category,
+			"task":     e.Task,
+			"note":     e.Note,
+			roundedCol: hours(e.Duration()),
+		}
+		result = append(result, pick(values, cols))
+	}
+	return result
+}
+
+func dayRows(events []*event.Event, cols []string) [][]string {
+	var dates []string
+	raw := map[string]time.Duration{}
+	rounded := map[string]time.Duration{}
+	for _, e := range events {
+		date := eventDate(e)
+		if _, ok := raw[date]; !ok {
+			dates = append(dates, date)
+		}
+		raw[date] += rawDuration(e)
+		rounded[date] += e.Duration()
+	}
+
+	var result [][]string
+	for _, date := range dates {
+		values := map[string]string{
+			"date":     date,
+			"hours":    hours(raw[date]),
+			"minutes":  minutes(raw[date]),
+			roundedCol: hours(rounded[date]),
+		}
+		result = append(result, pick(values, cols))
+	}
+	return result
+}
+
+func pick(values map[string]string, cols []string) []string {
+	row := make([]string, len(cols))
+	for i, c := range cols {
+		row[i] = values[c]
+	}
+	return row
+}
+
+// replaceDays merges new rows into the existing rows of a file, replacing the
+// rows of the days that are exported again. The first column is the date, and
+// the rows are kept in date order.
+func replaceDays(existing, added [][]string) [][]string {
+	dates := map[string]bool{}
+	for _, row := range added {
+		dates[row[0]] = true
+	}
+
+	var result [][]string
+	for _, row := range existing {
+		if len(row) > 0 && !dates[row[0]] {
+			result = append(result, row)
+		}
+	}
+	result = append(result, added...)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i][0] < result[j][0]
+	})
+	return result
+}
@@ -0,0 +1,157 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func dayEvents(day int, tasks ...string) []*event.Event {
+	var events []*event.Event
+	start := time.Date(2024, time.March, day, 9, 0, 0, 0, time.UTC)
+	for _, task := range tasks {
+		e := &event.Event{
+			Date:     event.EventDate{Day: day, Month: 3, Year: 2024},
+			Start:    start,
+			End:      start.Add(90 * time.Minute),
+			Category: "dev",
+			Task:     task,
+		}
+		e.DetermineHours()
+		events = append(events, e)
+		start = e.End
+	}
+	return events
+}
+
+func TestExport(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "hours", "export."+format)
+			exporter, err := NewExporter(format, map[string]string{"file": path, "rounded": "true"})
+			if err != nil {
+				t.Fatalf("NewExporter: %s", err)
+			}
+
+			// export the 2nd, then the 1st, then the 2nd again with other tasks
+			exports := [][]*event.Event{
+				dayEvents(2, "old", "older"),
+				dayEvents(1, "first, with a comma"),
+				dayEvents(2, "new & improved"),
+			}
+			for _, events := range exports {
+				err = exporter.Export(events)
+				if err != nil {
+					t.Fatalf("Export: %s", err)
+				}
+			}
+
+			c := exporter.(*Client)
+			records, err := c.file.read(path)
+			if err != nil {
+				t.Fatalf("read: %s", err)
+			}
+
+			expect := [][]string{
+				{"date", "start", "end", "hours", "minutes", "category", "task", "note", "rounded"},
+				{"2024-03-01", "09:00", "10:30", "1.50", "90", "dev", "first, with a comma", "", "1.50"},
+				{"2024-03-02", "09:00", "10:30", "1.50", "90", "dev", "new & improved", "", "1.50"},
+			}
+			if len(records) != len(expect) {
+				t.Fatalf("expected %d records, got %d: %q", len(expect), len(records), records)
+			}
+			for i := range expect {
+				// trailing empty cells aren't stored in xlsx files
+				for len(records[i]) < len(expect[i]) {
+					records[i] = append(records[i], "")
+				}
+				if !slices.Equal(records[i], expect[i]) {
+					t.Errorf("record %d:\nExpected: %q\nGot     : %q", i, expect[i], records[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExportDays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "days.csv")
+	exporter, err := NewExporter(FormatCSV, map[string]string{"file": path, "mode": "days"})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	err = exporter.Export(dayEvents(1, "a", "b"))
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %s", err)
+	}
+
+	expect := "date,hours,minutes\n2024-03-01,3.00,180\n"
+	if string(data) != expect {
+		t.Errorf("expected %q, got %q", expect, string(data))
+	}
+
+	// a file with other columns is left alone
+	other, err := NewExporter(FormatCSV, map[string]string{"file": path})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+	err = other.Export(dayEvents(2, "c"))
+	if err == nil {
+		t.Errorf("expected an error for mismatched columns")
+	}
+}
+
+func TestReadSharedStrings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saved.xlsx")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create: %s", err)
+	}
+
+	// the way spreadsheet applications save the file
+	zw := zip.NewWriter(f)
+	parts := map[string]string{
+		"xl/sharedStrings.xml": `<sst><si><t>date</t></si><si><r><t>hou</t></r><r><t>rs</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+			<row r="2"><c r="B2"><v>1.5</v></c></row>
+		</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	f.Close()
+
+	records, err := xlsxFile{}.read(path)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+
+	expect := [][]string{{"date", "hours"}, {"", "1.5"}}
+	if len(records) != 2 || !slices.Equal(records[0], expect[0]) || !slices.Equal(records[1], expect[1]) {
+		t.Errorf("expected %q, got %q", expect, records)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for col, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if columnName(col) != name {
+			t.Errorf("column %d: expected %s, got %s", col, name, columnName(col))
+		}
+		if columnIndex(name+"12") != col {
+			t.Errorf("%s: expected column %d, got %d", name, col, columnIndex(name+"12"))
+		}
+	}
+}
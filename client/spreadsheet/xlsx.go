@@ -0,0 +1,251 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// xlsxFile reads and writes sheets as a minimal Office Open XML workbook with
+// a single worksheet. Strings are written inline, but shared strings are read
+// too, since spreadsheet applications use them when saving the file.
+type xlsxFile struct {
+	numeric []bool // the columns written as numbers rather than text
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Hours" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+)
+
+func (x xlsxFile) write(path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("os.Create: %w", err)
+	}
+
+	zw := zip.NewWriter(f)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/worksheets/sheet1.xml", sheetXML(records, x.numeric)},
+	}
+
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("zip.Create(%s): %w", part.name, err)
+		}
+		_, err = io.WriteString(w, part.content)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("writing %s: %w", part.name, err)
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("zip.Close: %w", err)
+	}
+
+	return f.Close()
+}
+
+// sheetXML renders the worksheet. The header row is always text, and the
+// numeric columns of the other rows are written as numbers.
+func sheetXML(records [][]string, numeric []bool) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	for r, record := range records {
+		fmt.Fprintf(&sb, `<row r="%d">`, r+1)
+		for c, value := range record {
+			ref := cellRef(c, r)
+			if value == "" {
+				continue
+			}
+
+			if r > 0 && c < len(numeric) && numeric[c] {
+				if _, err := strconv.ParseFloat(value, 64); err == nil {
+					fmt.Fprintf(&sb, `<c r="%s"><v>%s</v></c>`, ref, value)
+					continue
+				}
+			}
+
+			fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(value))
+		}
+		sb.WriteString(`</row>`)
+	}
+
+	sb.WriteString(`</sheetData></worksheet>`)
+	return sb.String()
+}
+
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// cellRef returns the A1-style reference of a cell.
+func cellRef(col, row int) string {
+	return columnName(col) + strconv.Itoa(row+1)
+}
+
+func columnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+// columnIndex returns the zero-based column of an A1-style reference.
+func columnIndex(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+	}
+	return col - 1
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref     string `xml:"r,attr"`
+			Type    string `xml:"t,attr"`
+			Value   string `xml:"v"`
+			Inline  string `xml:"is>t"`
+			InlineR []struct {
+				Text string `xml:"t"`
+			} `xml:"is>r"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type xlsxSharedStrings struct {
+	Items []struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+func (xlsxFile) read(path string) ([][]string, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var shared []string
+	var sheet xlsxSheet
+	foundSheet := false
+	for _, f := range zr.File {
+		switch f.Name {
+		case "xl/sharedStrings.xml":
+			var ss xlsxSharedStrings
+			err = readXML(f, &ss)
+			if err != nil {
+				return nil, fmt.Errorf("reading shared strings: %w", err)
+			}
+			for _, si := range ss.Items {
+				text := si.Text
+				for _, run := range si.Runs {
+					text += run.Text
+				}
+				shared = append(shared, text)
+			}
+		case "xl/worksheets/sheet1.xml":
+			err = readXML(f, &sheet)
+			if err != nil {
+				return nil, fmt.Errorf("reading the worksheet: %w", err)
+			}
+			foundSheet = true
+		}
+	}
+
+	if !foundSheet {
+		return nil, fmt.Errorf("%s has no worksheet", path)
+	}
+
+	var records [][]string
+	for _, row := range sheet.Rows {
+		var record []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.Ref != "" {
+				col = columnIndex(cell.Ref)
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+
+			switch cell.Type {
+			case "inlineStr":
+				value := cell.Inline
+				for _, run := range cell.InlineR {
+					value += run.Text
+				}
+				record[col] = value
+			case "s":
+				idx, err := strconv.Atoi(cell.Value)
+				if err != nil || idx >= len(shared) {
+					return nil, fmt.Errorf("invalid shared string reference in %s", cell.Ref)
+				}
+				record[col] = shared[idx]
+			default:
+				record[col] = cell.Value
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func readXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
@@ -4,10 +4,12 @@ import (
 	"fmt"
 
 	"github.com/sporadisk/clocker/format"
+	"github.com/sporadisk/clocker/locale"
 )
 
 type Client struct {
 	TimeFormat string
+	Locale     *locale.Locale
 }
 
 func (c *Client) Init() error {
@@ -19,5 +21,12 @@ func (c *Client) Init() error {
 	if err != nil {
 		return fmt.Errorf("ValidateTimeFormat: %w", err)
 	}
+
+	if c.Locale == nil {
+		c.Locale, err = locale.Load(locale.English)
+		if err != nil {
+			return fmt.Errorf("locale.Load: %w", err)
+		}
+	}
 	return nil
 }
@@ -28,38 +28,62 @@ func (c *Client) Summary(sum summary.Summary) (string, error) {
 	var sb strings.Builder
 
 	if !sum.Valid {
-		sb.WriteString("Could not parse input:\n" + sum.ValidationMsg + "\n")
+		sb.WriteString(c.Locale.Sprintf("Could not parse input") + ":\n" + sum.ValidationMsg + "\n")
 		return sb.String(), ErrInvalidInput
 	}
 
 	if sum.Valid {
-		sb.WriteString("\n- Summary / " + summaryDate(&sum) + " -\n")
+		sb.WriteString("\n- " + c.Locale.Sprintf("Summary") + " / " + c.summaryDate(&sum) + " -\n")
 
 		if len(sum.Categories) > 0 {
-			sb.WriteString("\nCategories:\n")
+			sb.WriteString("\n" + c.Locale.Sprintf("Categories") + ":\n")
 			for _, cat := range sum.Categories {
 				sb.WriteString(fmt.Sprintf(" - %s: %s\n", cat.Name, c.formatDuration(cat.TimeWorked)))
 			}
 			sb.WriteString("\n")
 		}
 
-		sb.WriteString("Worked: " + c.formatDuration(sum.TimeWorked) + "\n")
+		sb.WriteString(c.Locale.Sprintf("Worked") + ": " + c.formatDuration(sum.TimeWorked) + "\n")
 
 		if sum.TimeLeft != nil {
-			sb.WriteString("Remaining: " + c.formatDuration(*sum.TimeLeft) + "\n")
+			sb.WriteString(c.Locale.Sprintf("Remaining") + ": " + c.formatDuration(*sum.TimeLeft) + "\n")
 		}
 
 		if sum.FullDayAt != nil {
-			sb.WriteString("Full day: " + format.Timestamp(*sum.FullDayAt) + "\n")
+			sb.WriteString(c.Locale.Sprintf("Full day") + ": " + format.Timestamp(*sum.FullDayAt) + "\n")
 		}
 
 		if sum.Surplus != nil {
-			sb.WriteString("Full day + " + c.formatDuration(*sum.Surplus) + "\n")
+			sb.WriteString(c.Locale.Sprintf("Full day + %s", c.formatDuration(*sum.Surplus)) + "\n")
+		}
+
+		if sum.BreakTime > 0 {
+			sb.WriteString(c.Locale.Sprintf("Breaks") + ": " + c.formatDuration(sum.BreakTime))
+			if sum.PaidBreakTime > 0 {
+				sb.WriteString(" (" + c.Locale.Sprintf("%s paid", c.formatDuration(sum.PaidBreakTime)) + ")")
+			}
+			sb.WriteString("\n")
+		}
+
+		if sum.FlexUsed > 0 {
+			sb.WriteString(c.Locale.Sprintf("Flex used") + ": " + c.formatDuration(sum.FlexUsed) + "\n")
+		}
+
+		if sum.FlexEarned > 0 {
+			sb.WriteString(c.Locale.Sprintf("Flex earned") + ": " + c.formatDuration(sum.FlexEarned) + "\n")
+		}
+
+		if len(sum.RateClasses) > 0 {
+			sb.WriteString("\n" + c.Locale.Sprintf("Rates") + ":\n")
+			for _, rc := range sum.RateClasses {
+				sb.WriteString(fmt.Sprintf(" - %s (%gx): %s\n", rc.Name, rc.Multiplier, c.formatDuration(rc.TimeWorked)))
+			}
+			sb.WriteString(c.Locale.Sprintf("Weighted") + ": " + c.formatDuration(sum.WeightedTime) + "\n")
 		}
 	}
 
 	if len(sum.Warnings) > 0 {
-		sb.WriteString("\nWarnings:\n")
+		sb.WriteString("\n" + c.Locale.Sprintf("Warnings") + ":\n")
 		for i, w := range sum.Warnings {
 			sb.WriteString(fmt.Sprintf(" %d - %s\n", i+1, w))
 		}
@@ -69,17 +93,10 @@ func (c *Client) Summary(sum summary.Summary) (string, error) {
 }
 
 func (c *Client) formatDuration(d time.Duration) string {
-	switch c.TimeFormat {
-	case format.TimeM:
-		return format.DurationM(d)
-	case format.TimeHMS:
-		return format.DurationHMS(d)
-	default:
-		return format.DurationHM(d)
-	}
+	return format.Duration(d, c.TimeFormat)
 }
 
-func summaryDate(sum *summary.Summary) string {
+func (c *Client) summaryDate(sum *summary.Summary) string {
 
 	if sum.Date == nil || sum.Date.Day == 0 || sum.Date.Month == 0 {
 		return format.Timestamp(time.Now())
@@ -89,5 +106,8 @@ func summaryDate(sum *summary.Summary) string {
 		sum.Date.Year = time.Now().Year()
 	}
 
-	return fmt.Sprintf("%s %02d.%02d.%d", sum.Date.DayName, sum.Date.Day, sum.Date.Month, sum.Date.Year)
+	// the weekday is derived from the date rather than the header text, so that
+	// it matches the locale
+	date := time.Date(sum.Date.Year, time.Month(sum.Date.Month), sum.Date.Day, 0, 0, 0, 0, time.Local)
+	return c.Locale.Date(date)
 }
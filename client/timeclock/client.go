@@ -0,0 +1,176 @@
+package timeclock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+const (
+	timestampLayout = "2006/01/02 15:04:05"
+
+	// exportTag marks the clock-ins written by clocker, as an hledger tag at
+	// the end of the comment.
+	exportTag = "clocker:"
+)
+
+// Client exports events to a timeclock file, as read by ledger and hledger.
+// Exporting a day again replaces the clock-ins and clock-outs that clocker
+// wrote for that day, and leaves the rest of the file alone.
+type Client struct {
+	// Configuration
+	Path string
+}
+
+func (c *Client) Init() error {
+	if c.Path == "" {
+		return errors.New("no file given")
+	}
+	return nil
+}
+
+func (c *Client) Export(events []*event.Event) error {
+	var timed []*event.Event
+	skipped := 0
+	dates := map[string]bool{}
+	starts := map[string]bool{}
+	for _, e := range event.Timed(events) {
+		if e.Start.IsZero() || e.End.IsZero() {
+			skipped++
+			continue
+		}
+		timed = append(timed, e)
+		dates[eventDate(e)] = true
+		starts[e.Start.Format(timestampLayout)] = true
+	}
+
+	if skipped > 0 {
+		fmt.Printf("Skipped %d events without a start and end time.\n", skipped)
+	}
+	if len(timed) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].Start.Before(timed[j].Start)
+	})
+
+	existing, err := c.read()
+	if err != nil {
+		return err
+	}
+
+	lines := removeExported(existing, dates, starts)
+	for _, e := range timed {
+		lines = append(lines, clockLines(e)...)
+	}
+
+	err = c.write(lines)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d clock entries to %s.\n", len(timed), c.Path)
+	return nil
+}
+
+func eventDate(e *event.Event) string {
+	return fmt.Sprintf("%04d/%02d/%02d", e.Date.Year, e.Date.Month, e.Date.Day)
+}
+
+// clockLines renders the clock-in and clock-out of an event. The category is
+// used as the account, the task as the description and the note as a comment,
+// which ends with the export tag.
+func clockLines(e *event.Event) []string {
+	in := fmt.Sprintf("i %s %s", e.Start.Format(timestampLayout), account(e.Category))
+	if e.Task != "" {
+		in += "  " + singleLine(e.Task)
+	}
+	if e.Note != "" {
+		in += "  ; " + singleLine(e.Note) + ", " + exportTag
+	} else {
+		in += "  ; " + exportTag
+	}
+
+	return []string{in, "o " + e.End.Format(timestampLayout)}
+}
+
+// account makes the category usable as an account name, which ends at two
+// spaces or a tab.
+func account(category string) string {
+	return strings.Join(strings.Fields(category), " ")
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, ";", ",")), " ")
+}
+
+// removeExported removes the clock-ins that clocker wrote on the dates, or at
+// the start times of the exported events, along with the clock-out that
+// follows each of them. Matching the start times catches the clock-ins past
+// midnight. Clock-ins without the export tag were entered by hand, and are
+// kept along with everything else.
+func removeExported(lines []string, dates, starts map[string]bool) []string {
+	var kept []string
+	removing := false
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 {
+			switch fields[0] {
+			case "i", "I":
+				date := strings.ReplaceAll(fields[1], "-", "/")
+				exported := strings.HasSuffix(strings.TrimSpace(line), exportTag)
+				removing = exported && (dates[date] || (len(fields) >= 3 && starts[date+" "+fields[2]]))
+				if removing {
+					continue
+				}
+			case "o", "O":
+				if removing {
+					removing = false
+					continue
+				}
+			}
+		}
+
+		kept = append(kept, line)
+	}
+
+	// don't let the file grow with blank lines from earlier exports
+	for len(kept) > 0 && strings.TrimSpace(kept[len(kept)-1]) == "" {
+		kept = kept[:len(kept)-1]
+	}
+
+	return kept
+}
+
+func (c *Client) read() ([]string, error) {
+	data, err := os.ReadFile(c.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), nil
+}
+
+func (c *Client) write(lines []string) error {
+	err := os.MkdirAll(filepath.Dir(c.Path), 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	err = os.WriteFile(c.Path, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return nil
+}
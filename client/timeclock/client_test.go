@@ -0,0 +1,88 @@
+package timeclock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func testEvent(day, hour int, category, task string) *event.Event {
+	start := time.Date(2025, time.October, day, hour, 0, 0, 0, time.Local)
+	e := &event.Event{
+		Date:     event.EventDate{Day: day, Month: 10, Year: 2025},
+		Start:    start,
+		End:      start.Add(90 * time.Minute),
+		Category: category,
+		Task:     task,
+	}
+	e.DetermineHours()
+	return e
+}
+
+func TestExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work.timeclock")
+	// a hand-written entry on the exported day, with a comment inside it, and
+	// an earlier export of the day
+	original := "; hours\ni 2025/10/16 09:00:00 admin\no 2025/10/16 10:00:00\n" +
+		"i 2025/10/17 06:00:00 ops  on call\n; paged twice\no 2025/10/17 07:00:00\n" +
+		"i 2025/10/17 07:00:00 old  ; clocker:\no 2025/10/17 08:00:00\n"
+	err := os.WriteFile(path, []byte(original), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Client{Path: path}
+	err = c.Init()
+	if err != nil {
+		t.Fatalf("c.Init: %s", err)
+	}
+
+	review := testEvent(17, 8, "dev", "code review")
+	review.Note = "API; backend"
+	err = c.Export([]*event.Event{
+		testEvent(17, 12, "meeting", ""),
+		review,
+		{Date: event.EventDate{Day: 17, Month: 10, Year: 2025}, Category: "duration only", Hours: 1},
+	})
+	if err != nil {
+		t.Fatalf("c.Export: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `; hours
+i 2025/10/16 09:00:00 admin
+o 2025/10/16 10:00:00
+i 2025/10/17 06:00:00 ops  on call
+; paged twice
+o 2025/10/17 07:00:00
+i 2025/10/17 08:00:00 dev  code review  ; API, backend, clocker:
+o 2025/10/17 09:30:00
+i 2025/10/17 12:00:00 meeting  ; clocker:
+o 2025/10/17 13:30:00
+`
+	if string(data) != expect {
+		t.Errorf("expected:\n%s\ngot:\n%s", expect, data)
+	}
+
+	// exporting the day again shouldn't duplicate it
+	err = c.Export([]*event.Event{review})
+	if err != nil {
+		t.Fatalf("c.Export: %s", err)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(data), "\ni ") != 3 || !strings.Contains(string(data), "ops  on call") {
+		t.Errorf("expected the day to be replaced, got:\n%s", data)
+	}
+}
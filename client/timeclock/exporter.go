@@ -0,0 +1,35 @@
+package timeclock
+
+import (
+	"fmt"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "timeclock",
+		Description: "Writes the events to a ledger/hledger timeclock file",
+		Required:    []string{"file"},
+		Factory:     NewExporter,
+	})
+}
+
+// NewExporter creates a timeclock client from exporter params.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "file")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		Path: p["file"],
+	}
+
+	err = client.Init()
+	if err != nil {
+		return nil, fmt.Errorf("timeclock.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
@@ -104,11 +104,17 @@ func (c *Client) eventToTimelyEvent(e *event.Event) (*timelyPostEvent, error) {
 	}
 	te.LabelIDs = []int{label.ID}
 
+	te.Day = fmt.Sprintf("%04d-%02d-%02d", e.Date.Year, e.Date.Month, e.Date.Day)
+
+	// events logged as a duration only have no start or end time, in which
+	// case from/to are omitted from the payload
 	if e.Start.IsZero() || e.End.IsZero() {
-		return te, fmt.Errorf("event is missing start or end time")
+		if e.Hours == 0 && e.Minutes == 0 {
+			return te, fmt.Errorf("event has neither a duration nor a start and end time")
+		}
+		return te, nil
 	}
 
-	te.Day = fmt.Sprintf("%04d-%02d-%02d", e.Date.Year, e.Date.Month, e.Date.Day)
 	te.From = e.Start
 	te.To = e.End
 	return te, nil
@@ -124,11 +130,16 @@ func eventToNote(e *event.Event) string {
 	caser := cases.Title(language.English)
 	titleCat := caser.String(e.Category)
 
+	note := titleCat
 	if e.Task != "" {
-		return fmt.Sprintf("%s: %s", titleCat, e.Task)
+		note = fmt.Sprintf("%s: %s", titleCat, e.Task)
+	}
+
+	if e.Note != "" {
+		note = fmt.Sprintf("%s - %s", note, e.Note)
 	}
 
-	return titleCat
+	return note
 }
 
 func (c *Client) makeEventBatches(events []*timelyPostEvent, batchSize int) [][]*timelyPostEvent {
@@ -0,0 +1,61 @@
+package timely
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "timely",
+		Description: "Timely (timelyapp.com), authorized through OAuth in the browser",
+		Required:    []string{"applicationId", "secret", "callbackUrl"},
+		Optional:    []string{"accountId", "projectId"},
+		Factory:     NewExporter,
+	})
+}
+
+// NewExporter creates and initializes a Timely client from exporter params.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "applicationId", "secret", "callbackUrl")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		ApplicationID: p["applicationId"],
+		ClientSecret:  p["secret"],
+		CallbackURL:   p["callbackUrl"],
+	}
+
+	// accountId is optional
+	accountID, ok := params["accountId"]
+	if ok {
+		accountIDInt, err := strconv.Atoi(accountID)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse accountId as int: %w", err)
+		}
+		client.AccountID = accountIDInt
+	}
+
+	// projectId is required, but we handle that in the client, to make it
+	// easier to find a project-id to use.
+	projectId, ok := params["projectId"]
+	if ok {
+		projectIDInt, err := strconv.Atoi(projectId)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse projectId as int: %w", err)
+		}
+		client.ProjectID = projectIDInt
+	}
+
+	err = client.Init(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("timely.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,148 @@
+package timewarrior
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+// Client exports events to Timewarrior's data directory, which holds one file
+// of intervals per month. Exporting a day again replaces the intervals that
+// clocker wrote for that day, and leaves the rest alone.
+type Client struct {
+	// Configuration
+	Dir string // defaults to $TIMEWARRIORDB/data, or ~/.timewarrior/data
+}
+
+func (c *Client) Init() error {
+	if c.Dir != "" {
+		return nil
+	}
+
+	db := os.Getenv("TIMEWARRIORDB")
+	if db == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("os.UserHomeDir: %w", err)
+		}
+		db = filepath.Join(home, ".timewarrior")
+	}
+
+	c.Dir = filepath.Join(db, "data")
+	return nil
+}
+
+func (c *Client) Export(events []*event.Event) error {
+	var timed []interval
+	skipped := 0
+	dates := map[string]bool{}
+	for _, e := range event.Timed(events) {
+		if e.Start.IsZero() || e.End.IsZero() {
+			skipped++
+			continue
+		}
+
+		iv := toInterval(e)
+		timed = append(timed, iv)
+		dates[iv.date()] = true
+	}
+
+	if skipped > 0 {
+		fmt.Printf("Skipped %d events without a start and end time.\n", skipped)
+	}
+	if len(timed) == 0 {
+		return nil
+	}
+
+	// The intervals of a day may be spread over two files, as the files are
+	// split by the UTC month.
+	byFile := map[string][]interval{}
+	for date := range dates {
+		day, _ := time.ParseInLocation(time.DateOnly, date, time.Local)
+		byFile[fileName(day)] = nil
+		byFile[fileName(day.AddDate(0, 0, 1).Add(-time.Second))] = nil
+	}
+	for _, iv := range timed {
+		name := fileName(iv.start)
+		byFile[name] = append(byFile[name], iv)
+	}
+
+	names := make([]string, 0, len(byFile))
+	for name := range byFile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		err := c.update(filepath.Join(c.Dir, name), dates, byFile[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Wrote %d intervals to %s.\n", len(timed), c.Dir)
+	return nil
+}
+
+// fileName returns the name of the data file for intervals starting at t.
+func fileName(t time.Time) string {
+	return t.UTC().Format("2006-01") + ".data"
+}
+
+// update replaces the exported intervals on the dates in the data file with the
+// new intervals, keeping the file sorted by start time. The intervals tracked
+// in Timewarrior itself are kept.
+func (c *Client) update(path string, dates map[string]bool, intervals []interval) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	var kept []interval
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		iv, err := parseLine(line)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		if !iv.exported || !dates[iv.date()] {
+			kept = append(kept, iv)
+		}
+	}
+
+	if data == nil && len(intervals) == 0 {
+		return nil // don't create empty files
+	}
+
+	kept = append(kept, intervals...)
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].start.Before(kept[j].start)
+	})
+
+	var sb strings.Builder
+	for _, iv := range kept {
+		sb.WriteString(iv.line + "\n")
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	err = os.WriteFile(path, []byte(sb.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return nil
+}
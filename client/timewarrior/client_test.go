@@ -0,0 +1,98 @@
+package timewarrior
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func testEvent(day, hour int, category, task string) *event.Event {
+	start := time.Date(2025, time.October, day, hour, 0, 0, 0, time.Local)
+	e := &event.Event{
+		Date:     event.EventDate{Day: day, Month: 10, Year: 2025},
+		Start:    start,
+		End:      start.Add(time.Hour),
+		Category: category,
+		Task:     task,
+	}
+	e.DetermineHours()
+	return e
+}
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		"dev":            "dev",
+		"code review":    `"code review"`,
+		`say "hi"`:       `"say \"hi\""`,
+		"#1":             `"#1"`,
+		"":               `""`,
+		"  extra  space": `"extra space"`,
+	}
+
+	for in, expect := range tests {
+		got := quote(in)
+		if got != expect {
+			t.Errorf("quote(%q): expected %s, got %s", in, expect, got)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+	c := &Client{Dir: dir}
+	err := c.Init()
+	if err != nil {
+		t.Fatalf("c.Init: %s", err)
+	}
+
+	// intervals tracked in Timewarrior, one of them on the exported day, and an
+	// earlier export of the day
+	utc := func(e *event.Event) string {
+		return e.Start.UTC().Format(timestampLayout) + " - " + e.End.UTC().Format(timestampLayout)
+	}
+	tracked := "inc " + utc(testEvent(16, 9, "", "")) + ` # admin # "tracked in timew"`
+	sameDay := "inc " + utc(testEvent(17, 6, "", "")) + " # ops clocker-review"
+	path := filepath.Join(dir, fileName(testEvent(16, 9, "", "").Start))
+	err = os.WriteFile(path, []byte(tracked+"\n"+sameDay+"\n"+toInterval(testEvent(17, 7, "old", "")).line+"\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	review := testEvent(17, 8, "dev", "code review")
+	review.Note = "backend"
+	events := []*event.Event{testEvent(17, 12, "meeting", ""), review}
+	err = c.Export(events)
+	if err != nil {
+		t.Fatalf("c.Export: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := tracked + "\n" + sameDay + "\n" +
+		"inc " + utc(review) + ` # dev "code review" clocker # backend` + "\n" +
+		"inc " + utc(events[0]) + " # meeting clocker\n"
+	if string(data) != expect {
+		t.Errorf("expected:\n%s\ngot:\n%s", expect, data)
+	}
+
+	// exporting the day again shouldn't duplicate it
+	err = c.Export(events)
+	if err != nil {
+		t.Fatalf("c.Export: %s", err)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(data), "inc ") != 4 || !strings.Contains(string(data), sameDay) {
+		t.Errorf("expected the day to be replaced, got:\n%s", data)
+	}
+}
@@ -0,0 +1,30 @@
+package timewarrior
+
+import (
+	"fmt"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "timewarrior",
+		Description: "Writes the events to Timewarrior's data files",
+		Optional:    []string{"dir"},
+		Factory:     NewExporter,
+	})
+}
+
+// NewExporter creates a Timewarrior client from exporter params.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	client := &Client{
+		Dir: params["dir"],
+	}
+
+	err := client.Init()
+	if err != nil {
+		return nil, fmt.Errorf("timewarrior.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
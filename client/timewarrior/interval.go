@@ -0,0 +1,83 @@
+package timewarrior
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+const (
+	timestampLayout = "20060102T150405Z"
+
+	// exportTag is the last tag of the intervals written by clocker.
+	exportTag = "clocker"
+)
+
+// exportTagPattern finds the export tag at the end of the tags, before the
+// annotation if there is one.
+var exportTagPattern = regexp.MustCompile(`[^#] ` + exportTag + `(?: #|$)`)
+
+// interval is a line of a Timewarrior data file:
+//
+//	inc 20251017T061500Z - 20251017T093500Z # dev "code review" clocker # "annotation"
+//
+// The line is kept as it is, so that the tags and annotations of intervals
+// tracked in Timewarrior survive an export.
+type interval struct {
+	start    time.Time
+	line     string
+	exported bool // written by clocker
+}
+
+// date returns the local date the interval starts on.
+func (iv interval) date() string {
+	return iv.start.In(time.Local).Format(time.DateOnly)
+}
+
+// toInterval uses the category as the first tag, the task as the second and
+// the note as the annotation. The export tag comes last.
+func toInterval(e *event.Event) interval {
+	line := fmt.Sprintf("inc %s - %s #", e.Start.UTC().Format(timestampLayout), e.End.UTC().Format(timestampLayout))
+
+	tags := []string{e.Category}
+	if e.Task != "" {
+		tags = append(tags, e.Task)
+	}
+	for _, tag := range tags {
+		line += " " + quote(tag)
+	}
+	line += " " + exportTag
+
+	if e.Note != "" {
+		line += " # " + quote(e.Note)
+	}
+
+	return interval{start: e.Start, line: line, exported: true}
+}
+
+// quote quotes tags and annotations containing spaces, quotes or "#".
+func quote(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s != "" && !strings.ContainsAny(s, ` "#\`) {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// parseLine reads the start time of an interval line.
+func parseLine(line string) (interval, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "inc" {
+		return interval{}, fmt.Errorf("invalid interval %q", line)
+	}
+
+	start, err := time.Parse(timestampLayout, fields[1])
+	if err != nil {
+		return interval{}, fmt.Errorf("invalid start: %w", err)
+	}
+
+	return interval{start: start, line: line, exported: exportTagPattern.MatchString(line)}, nil
+}
@@ -0,0 +1,112 @@
+package toggl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/client"
+)
+
+// Client exports events to Toggl Track, through the v9 API.
+type Client struct {
+	// Configuration
+	ApiURL      string
+	ApiToken    string
+	WorkspaceID int                 // defaults to the user's default workspace
+	Projects    map[string]string   // project name or ID, by category
+	Tags        map[string][]string // tag names, by category. Unmapped categories are tagged with their name
+
+	// State
+	HttpClient *client.HttpClient
+	api        *client.JSONClient
+	projectIDs map[string]int // by lowercase project name
+}
+
+type me struct {
+	ID                 int `json:"id"`
+	DefaultWorkspaceID int `json:"default_workspace_id"`
+}
+
+type project struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c *Client) Init(ctx context.Context) error {
+	if c.HttpClient == nil {
+		c.HttpClient = client.NewHttpClient(10 * time.Second)
+	}
+	if c.ApiURL == "" {
+		c.ApiURL = "https://api.track.toggl.com/"
+	}
+
+	apiURL, err := url.Parse(c.ApiURL)
+	if err != nil {
+		return fmt.Errorf("invalid ApiURL: %w", err)
+	}
+	c.api = &client.JSONClient{
+		Http:    c.HttpClient,
+		BaseURL: apiURL.JoinPath("api/v9"),
+		Authorize: func(req *http.Request) {
+			req.SetBasicAuth(c.ApiToken, "api_token")
+		},
+	}
+
+	if c.WorkspaceID == 0 {
+		var user me
+		err = c.api.GetJSON("me", nil, &user)
+		if err != nil {
+			return fmt.Errorf("getJSON(me): %w", err)
+		}
+		c.WorkspaceID = user.DefaultWorkspaceID
+		fmt.Printf("Toggl workspace ID selected: %d\n", c.WorkspaceID)
+	}
+
+	err = c.getProjects()
+	if err != nil {
+		return fmt.Errorf("getProjects: %w", err)
+	}
+
+	for cat, p := range c.Projects {
+		_, err := c.projectID(p)
+		if err != nil {
+			return fmt.Errorf("project for category %q: %w", cat, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) getProjects() error {
+	var projects []project
+	err := c.api.GetJSON(fmt.Sprintf("workspaces/%d/projects", c.WorkspaceID), nil, &projects)
+	if err != nil {
+		return fmt.Errorf("getJSON(projects): %w", err)
+	}
+
+	c.projectIDs = map[string]int{}
+	for _, p := range projects {
+		c.projectIDs[strings.ToLower(p.Name)] = p.ID
+	}
+	return nil
+}
+
+// projectID resolves a configured project, given either by name or by ID.
+func (c *Client) projectID(p string) (int, error) {
+	id, ok := c.projectIDs[strings.ToLower(p)]
+	if ok {
+		return id, nil
+	}
+
+	id, err := strconv.Atoi(p)
+	if err == nil {
+		return id, nil
+	}
+
+	return 0, fmt.Errorf("the workspace has no project called %q", p)
+}
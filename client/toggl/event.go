@@ -0,0 +1,145 @@
+package toggl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+type timeEntry struct {
+	ID          int       `json:"id,omitempty"`
+	WorkspaceID int       `json:"workspace_id"`
+	ProjectID   int       `json:"project_id,omitempty"`
+	Description string    `json:"description"`
+	Start       time.Time `json:"start"`
+	Stop        time.Time `json:"stop"`
+	Duration    int64     `json:"duration"` // seconds
+	Tags        []string  `json:"tags,omitempty"`
+	CreatedWith string    `json:"created_with,omitempty"`
+}
+
+// Export creates a time entry for each event. Events that already have a
+// matching entry in Toggl, with the same start, duration and description, are
+// skipped, so that exporting a day twice doesn't create duplicates.
+func (c *Client) Export(events []*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var entries []*timeEntry
+	for _, e := range event.Timed(events) {
+		if e.Start.IsZero() {
+			fmt.Printf("Skipping %q: Toggl requires a start time\n", e.Description())
+			continue
+		}
+
+		te, err := c.eventToTimeEntry(e)
+		if err != nil {
+			return fmt.Errorf("eventToTimeEntry: %w", err)
+		}
+		entries = append(entries, te)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	existing, err := c.existingEntries(entries)
+	if err != nil {
+		return fmt.Errorf("existingEntries: %w", err)
+	}
+
+	created, skipped := 0, 0
+	for _, te := range entries {
+		if existing[entryKey(te)] {
+			skipped++
+			continue
+		}
+
+		body, err := json.Marshal(te)
+		if err != nil {
+			return fmt.Errorf("json.Marshal: %w", err)
+		}
+
+		_, err = c.api.Request(http.MethodPost, fmt.Sprintf("workspaces/%d/time_entries", c.WorkspaceID), nil, body)
+		if err != nil {
+			return fmt.Errorf("creating time entry %q: %w", te.Description, err)
+		}
+		created++
+	}
+
+	fmt.Printf("Created %d Toggl time entries, skipped %d that already existed.\n", created, skipped)
+	return nil
+}
+
+func (c *Client) eventToTimeEntry(e *event.Event) (*timeEntry, error) {
+	end := e.End
+	if end.IsZero() {
+		end = e.Start.Add(e.Duration())
+	}
+
+	te := &timeEntry{
+		WorkspaceID: c.WorkspaceID,
+		Description: e.Description(),
+		Start:       e.Start.UTC(),
+		Stop:        end.UTC(),
+		Duration:    int64(end.Sub(e.Start).Seconds()),
+		CreatedWith: "clocker",
+	}
+
+	category := strings.ToLower(e.Category)
+	if p, ok := c.Projects[category]; ok {
+		id, err := c.projectID(p)
+		if err != nil {
+			return nil, err
+		}
+		te.ProjectID = id
+	}
+
+	tags, ok := c.Tags[category]
+	if !ok && e.Category != "" {
+		tags = []string{e.Category}
+	}
+	te.Tags = tags
+
+	return te, nil
+}
+
+// existingEntries returns the keys of the entries already logged on the days
+// of the entries.
+func (c *Client) existingEntries(entries []*timeEntry) (map[string]bool, error) {
+	first, last := entries[0].Start, entries[0].Stop
+	for _, te := range entries {
+		if te.Start.Before(first) {
+			first = te.Start
+		}
+		if te.Stop.After(last) {
+			last = te.Stop
+		}
+	}
+
+	params := url.Values{}
+	params.Set("start_date", first.Format("2006-01-02"))
+	params.Set("end_date", last.AddDate(0, 0, 1).Format("2006-01-02"))
+
+	var existing []*timeEntry
+	err := c.api.GetJSON("me/time_entries", params, &existing)
+	if err != nil {
+		return nil, fmt.Errorf("getJSON(time_entries): %w", err)
+	}
+
+	keys := map[string]bool{}
+	for _, te := range existing {
+		keys[entryKey(te)] = true
+	}
+	return keys, nil
+}
+
+func entryKey(te *timeEntry) string {
+	return fmt.Sprintf("%d/%d/%s", te.Start.Unix(), te.Duration, te.Description)
+}
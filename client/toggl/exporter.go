@@ -0,0 +1,60 @@
+package toggl
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "toggl",
+		Description: "Toggl Track, authorized with an API token",
+		Required:    []string{"apiToken"},
+		Optional:    []string{"workspaceId", "apiUrl", "project.<category>", "tags.<category>"},
+		Factory:     NewExporter,
+	})
+}
+
+// NewExporter creates and initializes a Toggl client from exporter params.
+// Projects are mapped with "project.<category>" params, and tags with
+// comma-separated "tags.<category>" params.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "apiToken")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		ApiURL:   params["apiUrl"],
+		ApiToken: p["apiToken"],
+		Projects: event.PrefixedParams(params, "project."),
+		Tags:     map[string][]string{},
+	}
+
+	workspaceID, ok := params["workspaceId"]
+	if ok {
+		client.WorkspaceID, err = strconv.Atoi(workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse workspaceId as int: %w", err)
+		}
+	}
+
+	for cat, tags := range event.PrefixedParams(params, "tags.") {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				client.Tags[cat] = append(client.Tags[cat], tag)
+			}
+		}
+	}
+
+	err = client.Init(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("toggl.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,105 @@
+package toggl
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/client/clienttest"
+	"github.com/sporadisk/clocker/event"
+)
+
+// newFakeToggl is a stand-in for the parts of the Toggl API used by the
+// client, with the existing time entries of the user.
+func newFakeToggl(t *testing.T, existing []*timeEntry) *clienttest.FakeAPI {
+	return &clienttest.FakeAPI{
+		T: t,
+		Authorized: func(r *http.Request) bool {
+			user, pass, ok := r.BasicAuth()
+			return ok && user == "secret-token" && pass == "api_token"
+		},
+		Get: func(r *http.Request) any {
+			switch r.URL.Path {
+			case "/api/v9/me":
+				return me{ID: 1, DefaultWorkspaceID: 42}
+			case "/api/v9/workspaces/42/projects":
+				return []project{{ID: 7, Name: "Client A"}, {ID: 8, Name: "Internal"}}
+			case "/api/v9/me/time_entries":
+				if r.URL.Query().Get("start_date") != "2024-03-01" {
+					t.Errorf("unexpected start_date: %s", r.URL.Query().Get("start_date"))
+				}
+				return existing
+			}
+			return nil
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2024, time.March, 1, hour, 0, 0, 0, time.UTC)
+	}
+
+	fake := newFakeToggl(t, []*timeEntry{{
+		Description: "api",
+		Start:       at(8),
+		Stop:        at(10),
+		Duration:    7200,
+	}})
+	server := fake.NewServer()
+
+	exporter, err := NewExporter(map[string]string{
+		"apiUrl":            server.URL,
+		"apiToken":          "secret-token",
+		"project.client-a":  "Client A",
+		"project.internal":  "8",
+		"tags.internal":     "meeting, weekly",
+		"unrelated.setting": "x",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	events := []*event.Event{
+		{Start: at(8), End: at(10), Category: "client-a", Task: "api"},
+		{Start: at(10), End: at(11), Category: "internal", Task: "standup"},
+		{Start: at(11), End: at(12), Category: "admin"},
+		{Hours: 1, Category: "admin", Task: "no start time"},
+	}
+	for _, e := range events[:3] {
+		e.DetermineHours()
+	}
+
+	err = exporter.Export(events)
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	created := clienttest.Posted[timeEntry](fake, "/api/v9/workspaces/42/time_entries")
+	if len(created) != 2 {
+		t.Fatalf("expected 2 created entries, got %d", len(created))
+	}
+
+	internal := created[0]
+	if internal.ProjectID != 8 || internal.Duration != 3600 || len(internal.Tags) != 2 || internal.Tags[1] != "weekly" {
+		t.Errorf("unexpected entry: %+v", internal)
+	}
+
+	admin := created[1]
+	if admin.ProjectID != 0 || len(admin.Tags) != 1 || admin.Tags[0] != "admin" {
+		t.Errorf("unexpected entry: %+v", admin)
+	}
+}
+
+func TestUnknownProject(t *testing.T) {
+	server := newFakeToggl(t, nil).NewServer()
+
+	_, err := NewExporter(map[string]string{
+		"apiUrl":           server.URL,
+		"apiToken":         "secret-token",
+		"project.client-b": "Client B",
+	})
+	if err == nil {
+		t.Errorf("expected an error for an unknown project")
+	}
+}
@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sporadisk/clocker/client"
+	"github.com/sporadisk/clocker/event"
+)
+
+const (
+	ModeBatch = "batch" // one request with all the events
+	ModeEach  = "each"  // one request per event
+
+	// DefaultTemplate renders the events, or the event, as JSON.
+	DefaultTemplate = "{{json .}}"
+)
+
+// Client exports events by sending them to an HTTP endpoint. The request body
+// is rendered from a template, which gets the []*event.Event in the batch
+// mode, and each *event.Event in the each mode.
+type Client struct {
+	// Configuration
+	URL          string
+	Method       string
+	Headers      map[string]string
+	HeaderEnv    map[string]string // headers taken from environment variables, by header name
+	Template     string
+	Mode         string
+	SuccessCodes []int // empty for any 2xx status
+
+	// State
+	HttpClient *client.HttpClient
+	tmpl       *template.Template
+	headers    http.Header
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"minutes": func(e *event.Event) int {
+		return int(e.Duration().Minutes())
+	},
+	"seconds": func(e *event.Event) int {
+		return int(e.Duration().Seconds())
+	},
+	"hours": func(e *event.Event) float64 {
+		return e.Duration().Hours()
+	},
+	"date": func(e *event.Event) string {
+		return fmt.Sprintf("%04d-%02d-%02d", e.Date.Year, e.Date.Month, e.Date.Day)
+	},
+	"formatTime": func(layout string, t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	},
+}
+
+func (c *Client) Init() error {
+	if c.HttpClient == nil {
+		c.HttpClient = client.NewHttpClient(10 * time.Second)
+	}
+	if c.Method == "" {
+		c.Method = http.MethodPost
+	}
+	if c.Mode == "" {
+		c.Mode = ModeBatch
+	}
+	if c.Template == "" {
+		c.Template = DefaultTemplate
+	}
+
+	if c.Mode != ModeBatch && c.Mode != ModeEach {
+		return fmt.Errorf("unknown mode %q - Use %s or %s", c.Mode, ModeBatch, ModeEach)
+	}
+
+	tmpl, err := template.New("webhook").Funcs(templateFuncs).Parse(c.Template)
+	if err != nil {
+		return fmt.Errorf("template.Parse: %w", err)
+	}
+	c.tmpl = tmpl
+
+	c.headers = http.Header{}
+	c.headers.Set("Content-Type", "application/json")
+	for name, value := range c.Headers {
+		c.headers.Set(name, value)
+	}
+
+	for name, envVar := range c.HeaderEnv {
+		value, ok := os.LookupEnv(envVar)
+		if !ok {
+			return fmt.Errorf("the environment variable %s for the %s header is not set", envVar, name)
+		}
+		c.headers.Set(name, value)
+	}
+
+	return nil
+}
+
+// Export renders and sends the events.
+func (c *Client) Export(events []*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if c.Mode == ModeBatch {
+		err := c.send(events)
+		if err != nil {
+			return err
+		}
+	} else {
+		for i, e := range events {
+			err := c.send(e)
+			if err != nil {
+				return fmt.Errorf("event %d of %d: %w", i+1, len(events), err)
+			}
+		}
+	}
+
+	fmt.Printf("Sent %d events to %s.\n", len(events), c.URL)
+	return nil
+}
+
+func (c *Client) send(data any) error {
+	var body bytes.Buffer
+	err := c.tmpl.Execute(&body, data)
+	if err != nil {
+		return fmt.Errorf("tmpl.Execute: %w", err)
+	}
+
+	req, err := http.NewRequest(c.Method, c.URL, &body)
+	if err != nil {
+		return fmt.Errorf("http.NewRequest: %w", err)
+	}
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("HttpClient.Do: %w", err)
+	}
+
+	if !c.success(resp.Code) {
+		return fmt.Errorf("%s %s: unexpected status %d: %s", c.Method, c.URL, resp.Code, strings.TrimSpace(string(resp.Body)))
+	}
+
+	return nil
+}
+
+func (c *Client) success(code int) bool {
+	if len(c.SuccessCodes) == 0 {
+		return code >= 200 && code <= 299
+	}
+	return slices.Contains(c.SuccessCodes, code)
+}
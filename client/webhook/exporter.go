@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name:        "webhook",
+		Description: "Sends the events to an HTTP endpoint, in a body rendered from a Go template",
+		Required:    []string{"url"},
+		Optional: []string{"method", "mode", "template", "templateFile", "successCodes",
+			"header.<name>", "headerEnv.<name>"},
+		Factory: NewExporter,
+	})
+}
+
+// NewExporter creates a webhook client from exporter params. Headers are set
+// with "header.<name>" params, or taken from the environment variable named
+// by "headerEnv.<name>" params, which keeps secrets out of the config file.
+func NewExporter(params map[string]string) (event.Exporter, error) {
+	p, err := event.GetParams(params, "url")
+	if err != nil {
+		return nil, fmt.Errorf("event.GetParams: %w", err)
+	}
+
+	client := &Client{
+		URL:       p["url"],
+		Method:    strings.ToUpper(params["method"]),
+		Mode:      strings.ToLower(params["mode"]),
+		Template:  params["template"],
+		Headers:   event.PrefixedParams(params, "header."),
+		HeaderEnv: event.PrefixedParams(params, "headerEnv."),
+	}
+
+	templateFile, ok := params["templateFile"]
+	if ok {
+		if client.Template != "" {
+			return nil, fmt.Errorf("template and templateFile can not both be set")
+		}
+
+		tmpl, err := os.ReadFile(templateFile)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+		client.Template = string(tmpl)
+	}
+
+	for _, code := range strings.Split(params["successCodes"], ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+
+		c, err := strconv.Atoi(code)
+		if err != nil {
+			return nil, fmt.Errorf("can't parse success code %q as int: %w", code, err)
+		}
+		client.SuccessCodes = append(client.SuccessCodes, c)
+	}
+
+	err = client.Init()
+	if err != nil {
+		return nil, fmt.Errorf("webhook.Client.Init: %w", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/event"
+)
+
+type request struct {
+	method string
+	header http.Header
+	body   string
+}
+
+func newTestServer(t *testing.T, status int) (*httptest.Server, *[]request) {
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{r.Method, r.Header, string(body)})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func testEvents() []*event.Event {
+	start := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	date := event.EventDate{Day: 1, Month: 3, Year: 2024}
+	return []*event.Event{
+		{Date: date, Start: start, End: start.Add(90 * time.Minute), Hours: 1, Minutes: 30, Category: "dev", Task: "api"},
+		{Date: date, Minutes: 15, Category: "admin"},
+	}
+}
+
+func TestExportEach(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusAccepted)
+	t.Setenv("WEBHOOK_TEST_TOKEN", "Bearer from-env")
+
+	exporter, err := NewExporter(map[string]string{
+		"url":                     server.URL,
+		"method":                  "put",
+		"mode":                    "each",
+		"template":                `{"day":"{{date .}}","start":"{{formatTime "15:04" .Start}}","minutes":{{minutes .}},"task":{{json .Task}}}`,
+		"header.X-Source":         "clocker",
+		"headerEnv.Authorization": "WEBHOOK_TEST_TOKEN",
+		"successCodes":            "202",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	err = exporter.Export(testEvents())
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(*requests))
+	}
+
+	first := (*requests)[0]
+	if first.method != http.MethodPut || first.header.Get("X-Source") != "clocker" || first.header.Get("Authorization") != "Bearer from-env" {
+		t.Errorf("unexpected request: %s %v", first.method, first.header)
+	}
+
+	expect := `{"day":"2024-03-01","start":"09:00","minutes":90,"task":"api"}`
+	if first.body != expect {
+		t.Errorf("unexpected body:\nExpected: %s\nGot     : %s", expect, first.body)
+	}
+
+	second := (*requests)[1].body
+	if second != `{"day":"2024-03-01","start":"","minutes":15,"task":""}` {
+		t.Errorf("unexpected body: %s", second)
+	}
+}
+
+func TestExportBatch(t *testing.T) {
+	server, requests := newTestServer(t, http.StatusOK)
+
+	templateFile := filepath.Join(t.TempDir(), "payload.tmpl")
+	err := os.WriteFile(templateFile, []byte(`{{range $i, $e := .}}{{if $i}},{{end}}{{$e.Category}}={{minutes $e}}{{end}}`), 0600)
+	if err != nil {
+		t.Fatalf("os.WriteFile: %s", err)
+	}
+
+	exporter, err := NewExporter(map[string]string{
+		"url":          server.URL,
+		"templateFile": templateFile,
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	err = exporter.Export(testEvents())
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	if len(*requests) != 1 || (*requests)[0].body != "dev=90,admin=15" || (*requests)[0].method != http.MethodPost {
+		t.Errorf("unexpected requests: %+v", *requests)
+	}
+}
+
+func TestExportFailure(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK)
+
+	exporter, err := NewExporter(map[string]string{
+		"url":          server.URL,
+		"successCodes": "201",
+	})
+	if err != nil {
+		t.Fatalf("NewExporter: %s", err)
+	}
+
+	err = exporter.Export(testEvents())
+	if err == nil {
+		t.Errorf("expected an error for a status that isn't a success code")
+	}
+
+	_, err = NewExporter(map[string]string{
+		"url":                     server.URL,
+		"headerEnv.Authorization": "WEBHOOK_TEST_UNSET",
+	})
+	if err == nil {
+		t.Errorf("expected an error for an unset environment variable")
+	}
+}
@@ -4,16 +4,39 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/sporadisk/clocker/calculator"
 	"github.com/sporadisk/clocker/client/logfile"
 	"github.com/sporadisk/clocker/config"
+	"github.com/sporadisk/clocker/event"
+
+	// exporters register themselves when imported
+	_ "github.com/sporadisk/clocker/client/harvest"
+	_ "github.com/sporadisk/clocker/client/ical"
+	_ "github.com/sporadisk/clocker/client/jira"
+	_ "github.com/sporadisk/clocker/client/kimai"
+	_ "github.com/sporadisk/clocker/client/spreadsheet"
+	_ "github.com/sporadisk/clocker/client/timeclock"
+	_ "github.com/sporadisk/clocker/client/timely"
+	_ "github.com/sporadisk/clocker/client/timewarrior"
+	_ "github.com/sporadisk/clocker/client/toggl"
+	_ "github.com/sporadisk/clocker/client/webhook"
 )
 
 const helpMsg = `
+Usage:
+  clocker [flags]
+  clocker exporters
+    List the available exporters, and the params they take.
+
 Valid flags:
   --file
     Path to a file on the local FS, which will be watched for changes and used as input.
+  --dialect
+    The log dialect used in the file. Selected by the file extension by default.
+  --config
+    Path to a config file. Defaults to .clocker.yaml in the working directory or home directory.
 
 `
 
@@ -34,8 +57,14 @@ func main() {
 func run() (validInput bool, err error) {
 	confPath := flag.String("config", "", "Path to config file")
 	filePath := flag.String("file", "", "Path to a local file to watch for changes")
+	dialectName := flag.String("dialect", "", "Log dialect to parse the file with (selected by file extension by default)")
 	flag.Parse()
 
+	if flag.Arg(0) == "exporters" {
+		listExporters()
+		return true, nil
+	}
+
 	if *confPath != "" {
 		fmt.Printf("Using config file: %s\n", *confPath)
 	}
@@ -49,7 +78,17 @@ func run() (validInput bool, err error) {
 		return false, fmt.Errorf("--file argument is missing.")
 	}
 
-	subscriber, err := logfile.NewSubscriber(*filePath)
+	vocabulary, err := logfile.LoadVocabulary(conf.Log)
+	if err != nil {
+		return false, fmt.Errorf("logfile.LoadVocabulary: %w", err)
+	}
+
+	dialect := *dialectName
+	if dialect == "" && conf.Log != nil {
+		dialect = conf.Log.Dialect
+	}
+
+	subscriber, err := logfile.NewSubscriber(*filePath, dialect, vocabulary)
 	if err != nil {
 		return true, fmt.Errorf("logfile.NewSubscriber: %w", err)
 	}
@@ -66,3 +105,16 @@ func run() (validInput bool, err error) {
 
 	return true, nil
 }
+
+func listExporters() {
+	for _, spec := range event.Exporters() {
+		fmt.Printf("%s\n  %s\n", spec.Name, spec.Description)
+		if len(spec.Required) > 0 {
+			fmt.Printf("  Required params: %s\n", strings.Join(spec.Required, ", "))
+		}
+		if len(spec.Optional) > 0 {
+			fmt.Printf("  Optional params: %s\n", strings.Join(spec.Optional, ", "))
+		}
+		fmt.Println()
+	}
+}
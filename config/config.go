@@ -9,15 +9,38 @@ import (
 )
 
 type Config struct {
-	DefaulltFullDay string          `yaml:"defaultFullDay"`
-	Exporter        *ExporterConfig `yaml:"exporter"`
-	Output          *OutputConfig   `yaml:"output"`
-	Calc            *CalcConfig     `yaml:"calculator"`
+	DefaulltFullDay string            `yaml:"defaultFullDay"`
+	Exporter        *ExporterConfig   `yaml:"exporter"` // a single exporter, kept for compatibility with older configs
+	Exporters       []*ExporterConfig `yaml:"exporters"`
+	Output          *OutputConfig     `yaml:"output"`
+	Calc            *CalcConfig       `yaml:"calculator"`
+	Report          *ReportConfig     `yaml:"report"`
+	Log             *LogConfig        `yaml:"log"`
 }
 
 type ExporterConfig struct {
-	Name   string            `yaml:"name"`
-	Params map[string]string `yaml:"params"`
+	Name       string            `yaml:"name"`
+	Label      string            `yaml:"label"`      // shown when confirming the export, defaults to the name
+	Categories []string          `yaml:"categories"` // patterns such as "client-*", empty for every category
+	Params     map[string]string `yaml:"params"`
+	Rounding   *RoundingConfig   `yaml:"rounding"`
+}
+
+type RoundingConfig struct {
+	Mode       string `yaml:"mode"`      // nearest, up, down or minimum
+	Increment  string `yaml:"increment"` // e.g. 15m
+	Minimum    string `yaml:"minimum"`   // the minimum charge per event, defaults to the increment
+	Compensate bool   `yaml:"compensate"`
+}
+
+// ExporterConfigs returns the configured exporters, including the single
+// exporter of older configs.
+func (c *Config) ExporterConfigs() []*ExporterConfig {
+	var confs []*ExporterConfig
+	if c.Exporter != nil {
+		confs = append(confs, c.Exporter)
+	}
+	return append(confs, c.Exporters...)
 }
 
 type OutputConfig struct {
@@ -25,8 +48,76 @@ type OutputConfig struct {
 	Params map[string]string `yaml:"params"`
 }
 
+type ReportConfig struct {
+	Format string `yaml:"format"` // markdown or html
+	Dir    string `yaml:"dir"`
+}
+
+type LogConfig struct {
+	Dialect   string         `yaml:"dialect"`   // overrides dialect selection by file extension
+	Languages []string       `yaml:"languages"` // built-in keyword packs, such as "en" and "nb"
+	Keywords  *KeywordConfig `yaml:"keywords"`  // custom keywords, added to the language packs
+}
+
+type KeywordConfig struct {
+	Start   []string `yaml:"start"`
+	Stop    []string `yaml:"stop"`
+	Target  []string `yaml:"target"`
+	Flex    []string `yaml:"flex"`
+	FlexDay []string `yaml:"flexDay"`
+	Output  []string `yaml:"output"`
+
+	Connectives []string `yaml:"connectives"` // words that keep a trailing duration in the text, as in "back in 2h"
+}
+
 type CalcConfig struct {
-	CategoryParseMode string `yaml:"categoryParseMode"`
+	CategoryParseMode string            `yaml:"categoryParseMode"`
+	BreakCategories   []string          `yaml:"breakCategories"` // logged, but not counted as work
+	PaidBreaks        map[string]string `yaml:"paidBreaks"`      // the paid part of a break category per day, e.g. "lunch: 30m"
+	ExportBreaks      bool              `yaml:"exportBreaks"`
+	BreakRules        []BreakRuleConfig `yaml:"breakRules"`
+	MaxContinuousWork string            `yaml:"maxContinuousWork"`
+	MinBreakLength    string            `yaml:"minBreakLength"` // gaps between sessions shorter than this are not breaks
+	AutoLunch         *AutoLunchConfig  `yaml:"autoLunch"`
+	Limits            *LimitsConfig     `yaml:"limits"`
+	Rates             []RateConfig      `yaml:"rates"`
+	Events            *EventsConfig     `yaml:"events"`
+}
+
+// EventsConfig describes the processing of events before they are reported
+// and exported.
+type EventsConfig struct {
+	Merge       string   `yaml:"merge"`       // adjacent or task
+	MinDuration string   `yaml:"minDuration"` // drop events shorter than this, e.g. 5m
+	SplitAt     []string `yaml:"splitAt"`     // times of day to split events at, e.g. 00:00
+}
+
+// RateConfig is a window of time compensated at a different rate, e.g.
+// evenings at 1.5x. Windows without from and to times cover the whole day.
+type RateConfig struct {
+	Name       string   `yaml:"name"`
+	Days       []string `yaml:"days"`     // e.g. [sat, sun], or weekdays/weekend. Empty for every day
+	From       string   `yaml:"from"`     // e.g. 18:00
+	To         string   `yaml:"to"`       // e.g. 06:00, spanning midnight
+	Category   string   `yaml:"category"` // only applies to this category, e.g. on-call
+	Multiplier float64  `yaml:"multiplier"`
+}
+
+type LimitsConfig struct {
+	MaxDay      string `yaml:"maxDay"`      // e.g. 9h
+	MaxWeek     string `yaml:"maxWeek"`     // e.g. 40h
+	MinRest     string `yaml:"minRest"`     // minimum rest between days, e.g. 11h
+	HistoryFile string `yaml:"historyFile"` // defaults to ~/.clocker/history.json
+}
+
+type BreakRuleConfig struct {
+	After    string `yaml:"after"`    // e.g. 6h
+	MinBreak string `yaml:"minBreak"` // e.g. 30m
+}
+
+type AutoLunchConfig struct {
+	After    string `yaml:"after"`
+	Duration string `yaml:"duration"`
 }
 
 func Load(path string) (*Config, error) {
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"fmt"
 	"math"
 	"time"
 )
@@ -15,6 +16,9 @@ type Event struct {
 	End      time.Time
 	Category string
 	Task     string
+	Note     string
+	Raw      time.Duration // the duration before rounding, set by Rounding.Apply
+	Parts    []*Event      // the timed segments of an event merged across a gap, which has no times of its own
 }
 
 func (e *Event) DetermineHours() {
@@ -31,6 +35,48 @@ func (e *Event) DetermineHours() {
 	e.Minutes = int(totalMinutes) % 60
 }
 
+// SetDuration sets the hours and minutes of the event, for events that have a
+// duration but no start or end time.
+func (e *Event) SetDuration(d time.Duration) {
+	totalMinutes := math.Floor(d.Minutes())
+
+	e.Hours = int(math.Floor(totalMinutes / 60))
+	e.Minutes = int(totalMinutes) % 60
+}
+
+// Duration returns the duration of the event, based on its hours and minutes.
+func (e *Event) Duration() time.Duration {
+	return time.Duration(e.Hours)*time.Hour + time.Duration(e.Minutes)*time.Minute
+}
+
+// Description returns the task of the event, or the category if it has no
+// task, followed by the note.
+func (e *Event) Description() string {
+	desc := e.Task
+	if desc == "" {
+		desc = e.Category
+	}
+
+	if e.Note != "" {
+		desc = fmt.Sprintf("%s - %s", desc, e.Note)
+	}
+	return desc
+}
+
+// Timed replaces the events that were merged across a gap with their timed
+// parts, for the exporters that need a start and end time.
+func Timed(events []*Event) []*Event {
+	var timed []*Event
+	for _, e := range events {
+		if len(e.Parts) > 0 && (e.Start.IsZero() || e.End.IsZero()) {
+			timed = append(timed, e.Parts...)
+			continue
+		}
+		timed = append(timed, e)
+	}
+	return timed
+}
+
 type EventDate struct {
 	Day   int
 	Month int
@@ -0,0 +1,120 @@
+package event
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExporterFactory creates an exporter from the params of its configuration.
+type ExporterFactory func(params map[string]string) (Exporter, error)
+
+// ExporterSpec describes a registered exporter, and the params it takes.
+type ExporterSpec struct {
+	Name        string
+	Description string
+	Required    []string
+	Optional    []string
+	Factory     ExporterFactory
+}
+
+var exporters = map[string]ExporterSpec{}
+
+// RegisterExporter makes an exporter available by name. Exporters register
+// themselves from an init function in their package.
+func RegisterExporter(spec ExporterSpec) {
+	name := strings.ToLower(spec.Name)
+	if _, exists := exporters[name]; exists {
+		panic(fmt.Sprintf("exporter %q is already registered", name))
+	}
+
+	spec.Name = name
+	exporters[name] = spec
+}
+
+// LookupExporter finds a registered exporter by name.
+func LookupExporter(name string) (ExporterSpec, bool) {
+	spec, ok := exporters[strings.ToLower(strings.TrimSpace(name))]
+	return spec, ok
+}
+
+// Exporters returns all registered exporters, sorted by name.
+func Exporters() []ExporterSpec {
+	var specs []ExporterSpec
+	for _, spec := range exporters {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs
+}
+
+// CheckParams checks that all the required params are present.
+func (s *ExporterSpec) CheckParams(params map[string]string) error {
+	var missing []string
+	for _, key := range s.Required {
+		if _, ok := params[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing parameters for the %s exporter: %s", s.Name, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// NewExporter checks the params, and creates the exporter by name.
+func NewExporter(name string, params map[string]string) (Exporter, error) {
+	spec, ok := LookupExporter(name)
+	if !ok {
+		var names []string
+		for _, s := range Exporters() {
+			names = append(names, s.Name)
+		}
+		return nil, fmt.Errorf("unrecognized exporter: %s - Available exporters: %s", name, strings.Join(names, ", "))
+	}
+
+	err := spec.CheckParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter, err := spec.Factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s exporter: %w", spec.Name, err)
+	}
+
+	return exporter, nil
+}
+
+// GetParams returns the required params, or an error if any of them are
+// missing.
+func GetParams(params map[string]string, required ...string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, key := range required {
+		value, ok := params[key]
+		if !ok {
+			return nil, fmt.Errorf("missing parameter: %s", key)
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// PrefixedParams returns the params whose keys start with the prefix, keyed by
+// the rest of the key in lowercase. It's used for per-category mappings such
+// as "project.<category>".
+func PrefixedParams(params map[string]string, prefix string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range params {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if ok && rest != "" {
+			result[strings.ToLower(rest)] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package event
+
+import (
+	"testing"
+)
+
+type nopExporter struct{}
+
+func (nopExporter) Export(events []*Event) error { return nil }
+
+func TestRegistry(t *testing.T) {
+	RegisterExporter(ExporterSpec{
+		Name:     "Test",
+		Required: []string{"token"},
+		Optional: []string{"project"},
+		Factory: func(params map[string]string) (Exporter, error) {
+			return nopExporter{}, nil
+		},
+	})
+
+	_, ok := LookupExporter("test")
+	if !ok {
+		t.Fatalf("expected the exporter to be registered by its lowercase name")
+	}
+
+	_, err := NewExporter("test", map[string]string{"project": "x"})
+	if err == nil {
+		t.Errorf("expected an error for the missing token param")
+	}
+
+	_, err = NewExporter("test", map[string]string{"token": "x"})
+	if err != nil {
+		t.Errorf("NewExporter: %s", err)
+	}
+
+	_, err = NewExporter("nope", nil)
+	if err == nil {
+		t.Errorf("expected an error for an unknown exporter")
+	}
+}
@@ -0,0 +1,167 @@
+package event
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	RoundNearest = "nearest"
+	RoundUp      = "up"
+	RoundDown    = "down"
+	RoundMinimum = "minimum" // round to the nearest increment, but charge at least the minimum
+)
+
+// Rounding describes how event durations are rounded before they are
+// exported.
+type Rounding struct {
+	Mode      string
+	Increment time.Duration
+	Minimum   time.Duration // the minimum charge per event, in the minimum mode
+
+	// Compensate distributes the rounding between the events of each day by
+	// the largest remainder method, so that the rounded daily total is as close
+	// to the raw total as the increment allows. Only used in the nearest and
+	// minimum modes.
+	Compensate bool
+}
+
+// Validate checks the rounding mode, and fills in the default minimum.
+func (r *Rounding) Validate() error {
+	switch r.Mode {
+	case RoundNearest, RoundUp, RoundDown:
+	case RoundMinimum:
+		if r.Minimum == 0 {
+			r.Minimum = r.Increment
+		}
+	default:
+		return fmt.Errorf("unknown rounding mode: %q", r.Mode)
+	}
+
+	if r.Increment <= 0 {
+		return fmt.Errorf("the rounding increment must be positive")
+	}
+
+	if r.Compensate && (r.Mode == RoundUp || r.Mode == RoundDown) {
+		return fmt.Errorf("compensation can not be used with the %s mode", r.Mode)
+	}
+
+	return nil
+}
+
+// Apply returns rounded copies of the events, with the raw durations kept in
+// Raw. Rounded events keep their start time, and have their end time moved to
+// match the rounded duration. Events that are rounded down to nothing are left
+// out.
+func (r *Rounding) Apply(events []*Event) []*Event {
+	rounded := make([]*Event, 0, len(events))
+	for _, e := range events {
+		c := *e
+		c.Raw = e.Duration()
+		rounded = append(rounded, &c)
+	}
+
+	if r.Compensate {
+		for _, day := range groupByDate(rounded) {
+			r.compensate(day)
+		}
+	} else {
+		for _, e := range rounded {
+			e.setRounded(r.round(e.Raw))
+		}
+	}
+
+	result := make([]*Event, 0, len(rounded))
+	for _, e := range rounded {
+		if e.Duration() > 0 {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+func (r *Rounding) round(d time.Duration) time.Duration {
+	var rounded time.Duration
+	switch r.Mode {
+	case RoundUp:
+		rounded = (d + r.Increment - 1) / r.Increment * r.Increment
+	case RoundDown:
+		rounded = d / r.Increment * r.Increment
+	default:
+		rounded = (d + r.Increment/2) / r.Increment * r.Increment
+	}
+
+	return r.applyMinimum(d, rounded)
+}
+
+func (r *Rounding) applyMinimum(raw, rounded time.Duration) time.Duration {
+	if r.Mode == RoundMinimum && raw > 0 && rounded < r.Minimum {
+		return r.Minimum
+	}
+	return rounded
+}
+
+// compensate rounds the events of a single day by the largest remainder
+// method: every event is rounded down, and the increments that are left over
+// go to the events with the largest remainders.
+func (r *Rounding) compensate(events []*Event) {
+	total := time.Duration(0)
+	floored := time.Duration(0)
+	for _, e := range events {
+		total += e.Raw
+		floored += e.Raw / r.Increment * r.Increment
+	}
+
+	target := (total + r.Increment/2) / r.Increment * r.Increment
+	extra := int((target - floored) / r.Increment)
+
+	order := make([]int, len(events))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return events[order[i]].Raw%r.Increment > events[order[j]].Raw%r.Increment
+	})
+
+	for rank, i := range order {
+		e := events[i]
+		d := e.Raw / r.Increment * r.Increment
+		if rank < extra {
+			d += r.Increment
+		}
+		e.setRounded(r.applyMinimum(e.Raw, d))
+	}
+}
+
+func (e *Event) setRounded(d time.Duration) {
+	e.SetDuration(d)
+	if !e.Start.IsZero() {
+		e.End = e.Start.Add(d)
+	}
+}
+
+func groupByDate(events []*Event) [][]*Event {
+	var days [][]*Event
+	index := map[EventDate]int{}
+	for _, e := range events {
+		i, ok := index[e.Date]
+		if !ok {
+			i = len(days)
+			index[e.Date] = i
+			days = append(days, nil)
+		}
+		days[i] = append(days[i], e)
+	}
+	return days
+}
+
+// TotalDuration returns the sum of the event durations.
+func TotalDuration(events []*Event) time.Duration {
+	total := time.Duration(0)
+	for _, e := range events {
+		total += e.Duration()
+	}
+	return total
+}
@@ -0,0 +1,71 @@
+package event
+
+import (
+	"testing"
+	"time"
+)
+
+func testEvents(minutes ...int) []*Event {
+	var events []*Event
+	start := time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC)
+	for _, m := range minutes {
+		e := &Event{
+			Date:  EventDate{Day: 1, Month: 3, Year: 2024},
+			Start: start,
+			End:   start.Add(time.Duration(m) * time.Minute),
+		}
+		e.DetermineHours()
+		events = append(events, e)
+		start = e.End
+	}
+	return events
+}
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		rounding Rounding
+		minutes  []int
+		expect   []int
+	}{
+		{"nearest", Rounding{Mode: RoundNearest, Increment: 15 * time.Minute}, []int{7, 8, 22, 53}, []int{15, 15, 60}},
+		{"up", Rounding{Mode: RoundUp, Increment: 6 * time.Minute}, []int{1, 12, 13}, []int{6, 12, 18}},
+		{"down", Rounding{Mode: RoundDown, Increment: 15 * time.Minute}, []int{14, 44}, []int{30}},
+		{"minimum", Rounding{Mode: RoundMinimum, Increment: 15 * time.Minute, Minimum: 30 * time.Minute}, []int{5, 37}, []int{30, 30}},
+		// 20+20+20 rounds to 15+15+15 = 45m per event, but to 1h in total
+		{"compensated", Rounding{Mode: RoundNearest, Increment: 15 * time.Minute, Compensate: true}, []int{20, 20, 20}, []int{30, 15, 15}},
+		{"compensated remainders", Rounding{Mode: RoundNearest, Increment: 15 * time.Minute, Compensate: true}, []int{10, 14, 50}, []int{15, 15, 45}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rounding.Validate()
+			if err != nil {
+				t.Fatalf("Validate: %s", err)
+			}
+
+			events := testEvents(tc.minutes...)
+			rounded := tc.rounding.Apply(events)
+			if len(rounded) != len(tc.expect) {
+				t.Fatalf("expected %d events, got %d", len(tc.expect), len(rounded))
+			}
+
+			for i, e := range rounded {
+				expect := time.Duration(tc.expect[i]) * time.Minute
+				if e.Duration() != expect {
+					t.Errorf("event %d: expected %s, got %s", i, expect, e.Duration())
+				}
+				if e.End.Sub(e.Start) != expect {
+					t.Errorf("event %d: end time not moved to match the rounded duration", i)
+				}
+			}
+
+			// the original events are left alone
+			for i, e := range events {
+				if e.Duration() != time.Duration(tc.minutes[i])*time.Minute {
+					t.Errorf("event %d: the original event was modified", i)
+				}
+			}
+		})
+	}
+}
@@ -73,3 +73,16 @@ func DurationHMS(d time.Duration) string {
 
 	return sb.String()
 }
+
+// Duration formats a duration according to one of the duration formats
+// (TimeHMS, TimeHM or TimeM). Unknown formats fall back to TimeHM.
+func Duration(d time.Duration, timeFormat string) string {
+	switch timeFormat {
+	case TimeM:
+		return DurationM(d)
+	case TimeHMS:
+		return DurationHMS(d)
+	default:
+		return DurationHM(d)
+	}
+}
@@ -0,0 +1,188 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// Day is the record of a single logged day.
+type Day struct {
+	Date    string        `json:"date"`             // format: YYYY-MM-DD
+	FirstIn time.Time     `json:"firstIn,omitzero"` // dated, unlike log timestamps
+	LastOut time.Time     `json:"lastOut,omitzero"`
+	Worked  time.Duration `json:"worked"`
+}
+
+// Store keeps a record of the logged days in a JSON file, to support checks
+// that span more than one log file.
+type Store struct {
+	Path string
+
+	mu   sync.Mutex
+	days map[string]Day
+}
+
+// Open loads the store from the path. An empty path selects the default
+// location in the user's home directory.
+func Open(path string) (*Store, error) {
+	if path == "" {
+		defaultPath, err := defaultStorePath()
+		if err != nil {
+			return nil, fmt.Errorf("defaultStorePath: %w", err)
+		}
+		path = defaultPath
+	}
+
+	s := &Store{
+		Path: path,
+		days: map[string]Day{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && errors.Is(err, os.ErrNotExist) {
+		return s, nil // nothing recorded yet
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	var days []Day
+	err = json.Unmarshal(data, &days)
+	if err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	for _, d := range days {
+		s.days[d.Date] = d
+	}
+
+	return s, nil
+}
+
+// Record adds or replaces the record for a day, and saves the store.
+func (s *Store) Record(day Day) error {
+	_, err := time.Parse(dateFormat, day.Date)
+	if err != nil {
+		return fmt.Errorf("invalid date %q: %w", day.Date, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.days[day.Date] = day
+	return s.save()
+}
+
+// Day returns the record for the date (YYYY-MM-DD).
+func (s *Store) Day(date string) (Day, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.days[date]
+	return d, ok
+}
+
+// Previous returns the nearest recorded day before the date.
+func (s *Store) Previous(date string) (Day, bool) {
+	dates := s.sortedDates()
+	for i := len(dates) - 1; i >= 0; i-- {
+		if dates[i] < date {
+			return s.Day(dates[i])
+		}
+	}
+	return Day{}, false
+}
+
+// Next returns the nearest recorded day after the date.
+func (s *Store) Next(date string) (Day, bool) {
+	for _, d := range s.sortedDates() {
+		if d > date {
+			return s.Day(d)
+		}
+	}
+	return Day{}, false
+}
+
+// Week returns the recorded days in the ISO week of the date.
+func (s *Store) Week(date string) ([]Day, error) {
+	t, err := time.Parse(dateFormat, date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	year, week := t.ISOWeek()
+
+	var days []Day
+	for _, d := range s.sortedDates() {
+		dt, err := time.Parse(dateFormat, d)
+		if err != nil {
+			continue
+		}
+
+		y, w := dt.ISOWeek()
+		if y == year && w == week {
+			day, _ := s.Day(d)
+			days = append(days, day)
+		}
+	}
+
+	return days, nil
+}
+
+func (s *Store) sortedDates() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dates := make([]string, 0, len(s.days))
+	for d := range s.days {
+		dates = append(dates, d)
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// save writes the store to disk. The caller must hold the lock.
+func (s *Store) save() error {
+	days := make([]Day, 0, len(s.days))
+	for _, d := range s.days {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+
+	data, err := json.MarshalIndent(days, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+
+	err = os.WriteFile(s.Path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return nil
+}
+
+func defaultStorePath() (string, error) {
+	homedir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("os.UserHomeDir: %w", err)
+	}
+
+	dir := filepath.Join(homedir, ".clocker")
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", fmt.Errorf("os.MkdirAll(%s): %w", dir, err)
+	}
+
+	return filepath.Join(dir, "history.json"), nil
+}
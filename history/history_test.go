@@ -0,0 +1,61 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+
+	days := []Day{
+		{Date: "2024-03-01", Worked: 8 * time.Hour}, // friday, week 9
+		{Date: "2024-03-04", Worked: 7 * time.Hour}, // monday, week 10
+		{Date: "2024-03-05", Worked: 6 * time.Hour},
+	}
+	for _, d := range days {
+		err = s.Record(d)
+		if err != nil {
+			t.Fatalf("Record: %s", err)
+		}
+	}
+
+	// reopen, to check that the days were saved
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+
+	week, err := s.Week("2024-03-06")
+	if err != nil {
+		t.Fatalf("Week: %s", err)
+	}
+	if len(week) != 2 {
+		t.Errorf("expected 2 days in the week, got %d", len(week))
+	}
+
+	prev, ok := s.Previous("2024-03-04")
+	if !ok || prev.Date != "2024-03-01" {
+		t.Errorf("expected previous day 2024-03-01, got %q", prev.Date)
+	}
+
+	next, ok := s.Next("2024-03-04")
+	if !ok || next.Date != "2024-03-05" {
+		t.Errorf("expected next day 2024-03-05, got %q", next.Date)
+	}
+
+	_, ok = s.Next("2024-03-05")
+	if ok {
+		t.Errorf("expected no day after 2024-03-05")
+	}
+
+	err = s.Record(Day{Date: "march 6th"})
+	if err == nil {
+		t.Errorf("expected an error for an invalid date")
+	}
+}
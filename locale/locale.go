@@ -0,0 +1,181 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+const (
+	English   = "en"
+	Norwegian = "nb"
+)
+
+// Locale translates output strings, and formats dates and weekday names.
+type Locale struct {
+	Name       string
+	Tag        language.Tag
+	printer    *message.Printer
+	weekdays   [7]string
+	dateLayout string
+}
+
+type localeDef struct {
+	tag        language.Tag
+	weekdays   [7]string // Sunday first, matching time.Weekday
+	dateLayout string
+	messages   map[string]string
+}
+
+var locales = map[string]localeDef{
+	English: {
+		tag:        language.English,
+		weekdays:   [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		dateLayout: "02.01.2006",
+	},
+	Norwegian: {
+		tag:        language.Norwegian,
+		weekdays:   [7]string{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"},
+		dateLayout: "02.01.2006",
+		messages: map[string]string{
+			"Summary":               "Oppsummering",
+			"Could not parse input": "Kunne ikke tolke loggen",
+			"Categories":            "Kategorier",
+			"Worked":                "Arbeidet",
+			"Remaining":             "Gjenstår",
+			"Full day":              "Full dag",
+			"Full day + %s":         "Full dag + %s",
+			"Warnings":              "Advarsler",
+			"Flex used":             "Fleks brukt",
+			"Flex earned":           "Fleks opptjent",
+			"Breaks":                "Pauser",
+			"%s paid":               "%s betalt",
+			"Rates":                 "Satser",
+			"Weighted":              "Vektet",
+
+			// reports
+			"Time report %s":                         "Timerapport %s",
+			"Time report %s - %s":                    "Timerapport %s - %s",
+			"Category":                               "Kategori",
+			"Time":                                   "Tid",
+			"Rate":                                   "Sats",
+			"Multiplier":                             "Faktor",
+			"Start":                                  "Start",
+			"End":                                    "Slutt",
+			"Duration":                               "Varighet",
+			"Task":                                   "Oppgave",
+			"Target":                                 "Mål",
+			"Balance":                                "Saldo",
+			"Total":                                  "Totalt",
+			"%s (%s paid)":                           "%s (%s betalt)",
+			"Report written to %s":                   "Rapport skrevet til %s",
+			"Export %d log events (%s) to %s?":       "Eksportere %d hendelser (%s) til %s?",
+			"Export denied.":                         "Eksport avbrutt.",
+			"Export started.":                        "Eksport startet.",
+			"Export completed.":                      "Eksport fullført.",
+			"Export results:":                        "Eksportresultater:",
+			"no matching events":                     "ingen hendelser passer",
+			"denied":                                 "avbrutt",
+			"exported %d events":                     "eksporterte %d hendelser",
+			"failed: %s":                             "feilet: %s",
+			"Rounded to %s (%s): %s raw, %s rounded": "Avrundet til %s (%s): %s faktisk, %s avrundet",
+
+			// validation
+			"No valid time entries detected.":                                                            "Fant ingen gyldige tidsoppføringer.",
+			`"%s" entry on line %d follows a clock-in, which is wrong.`:                                  `"%s" på linje %d kommer etter en innstempling, som er feil.`,
+			`Clock-out at %s on line %d follows "%s", should follow a clock-in`:                          `Utstempling %s på linje %d kommer etter "%s", skulle kommet etter en innstempling`,
+			`Clock-out on line %d has an earlier timestamp than its corresponding "%s"`:                  `Utstemplingen på linje %d er tidligere enn tilhørende "%s"`,
+			`The time from %s to %s ending on line %d overlaps the time from %s to %s logged on line %d`: `Tiden fra %s til %s som slutter på linje %d overlapper tiden fra %s til %s logget på linje %d`,
+			`Time range on line %d starts before the range on line %d has ended`:                         `Tidsrommet på linje %d starter før tidsrommet på linje %d er over`,
+			`Time range on line %d ends without having started`:                                          `Tidsrommet på linje %d slutter uten å ha startet`,
+			`Time range on line %d overlaps the clock-in at %s`:                                          `Tidsrommet på linje %d overlapper innstemplingen %s`,
+			`Duplicate clock-in on line %d`:                                                              `Dobbel innstempling på linje %d`,
+			`Clock-in at %s on line %d occurs prior to the previous clock-out (%s)`:                      `Innstemplingen %s på linje %d er før forrige utstempling (%s)`,
+			`Flex time entry on line %d follows a clock-in, which is wrong.`:                             `Fleksoppføringen på linje %d kommer etter en innstempling, som er feil.`,
+			`Flex day entry on line %d follows a clock-in, which is wrong.`:                              `Fleksdagen på linje %d kommer etter en innstempling, som er feil.`,
+
+			// warnings
+			"Worked %s on %s: The daily maximum is %s":                                        "Arbeidet %s %s: Maksimum per dag er %s",
+			"Worked %s in the week of %s: The weekly maximum is %s":                           "Arbeidet %s i uken med %s: Maksimum per uke er %s",
+			"Only %s of rest between %s %s and %s %s: The minimum is %s":                      "Bare %s hvile mellom %s %s og %s %s: Minimum er %s",
+			"Less than %s of breaks logged after %s of work: Deducted %s for lunch":           "Mindre enn %s pause logget etter %s arbeid: Trakk fra %s for lunsj",
+			"Worked %s with %s of breaks: At least %s of breaks is required after %s of work": "Arbeidet %s med %s pause: Minst %s pause kreves etter %s arbeid",
+			"Worked %s without a break from %s to %s: The maximum is %s":                      "Arbeidet %s uten pause fra %s til %s: Maksimum er %s",
+			"no time": "ingen tid",
+		},
+	},
+}
+
+var langCatalog = buildCatalog()
+
+func buildCatalog() *catalog.Builder {
+	b := catalog.NewBuilder(catalog.Fallback(language.English))
+	for _, def := range locales {
+		for key, msg := range def.messages {
+			err := b.SetString(def.tag, key, msg)
+			if err != nil {
+				panic(fmt.Sprintf("catalog.SetString(%s, %q): %s", def.tag, key, err.Error()))
+			}
+		}
+	}
+	return b
+}
+
+// Available returns the names of the supported locales.
+func Available() []string {
+	return []string{English, Norwegian}
+}
+
+// Load returns the named locale. An empty name selects English.
+func Load(name string) (*Locale, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "":
+		name = English
+	case "no", "nn", "nb-no", "nb_no":
+		name = Norwegian
+	}
+
+	def, ok := locales[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported locale %q - Supported locales: %s", name, strings.Join(Available(), ", "))
+	}
+
+	return &Locale{
+		Name:       name,
+		Tag:        def.tag,
+		printer:    message.NewPrinter(def.tag, message.Catalog(langCatalog)),
+		weekdays:   def.weekdays,
+		dateLayout: def.dateLayout,
+	}, nil
+}
+
+// Sprintf translates the key and formats it according to the locale. A nil
+// locale formats the key as it is.
+func (l *Locale) Sprintf(key string, a ...any) string {
+	if l == nil {
+		return fmt.Sprintf(key, a...)
+	}
+	return l.printer.Sprintf(key, a...)
+}
+
+// Weekday returns the localized name of the weekday.
+func (l *Locale) Weekday(wd time.Weekday) string {
+	if l == nil {
+		return locales[English].weekdays[wd]
+	}
+	return l.weekdays[wd]
+}
+
+// Date formats the date along with its localized weekday name.
+func (l *Locale) Date(t time.Time) string {
+	layout := locales[English].dateLayout
+	if l != nil {
+		layout = l.dateLayout
+	}
+	return l.Weekday(t.Weekday()) + " " + t.Format(layout)
+}
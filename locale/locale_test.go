@@ -0,0 +1,57 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocales(t *testing.T) {
+	date := time.Date(2025, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		worked  string
+		surplus string
+		date    string
+	}{
+		{name: "", worked: "Worked", surplus: "Full day + 5m", date: "Friday 17.10.2025"},
+		{name: "en", worked: "Worked", surplus: "Full day + 5m", date: "Friday 17.10.2025"},
+		{name: "nb", worked: "Arbeidet", surplus: "Full dag + 5m", date: "fredag 17.10.2025"},
+		{name: "no", worked: "Arbeidet", surplus: "Full dag + 5m", date: "fredag 17.10.2025"},
+	}
+
+	for _, te := range tests {
+		t.Run(te.name, func(t *testing.T) {
+			l, err := Load(te.name)
+			if err != nil {
+				t.Errorf("Load: %s", err.Error())
+				return
+			}
+
+			if got := l.Sprintf("Worked"); got != te.worked {
+				t.Errorf("translation mismatch: expected %q, got %q", te.worked, got)
+			}
+
+			if got := l.Sprintf("Full day + %s", "5m"); got != te.surplus {
+				t.Errorf("translation mismatch: expected %q, got %q", te.surplus, got)
+			}
+
+			if got := l.Date(date); got != te.date {
+				t.Errorf("date mismatch: expected %q, got %q", te.date, got)
+			}
+		})
+	}
+
+	var none *Locale
+	if got := none.Sprintf("Worked %s", "5m"); got != "Worked 5m" {
+		t.Errorf("expected a nil locale to format the key as it is, got %q", got)
+	}
+	if got := none.Date(date); got != "Friday 17.10.2025" {
+		t.Errorf("expected a nil locale to format dates in English, got %q", got)
+	}
+
+	_, err := Load("klingon")
+	if err == nil {
+		t.Errorf("expected an error for an unsupported locale")
+	}
+}
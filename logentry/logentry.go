@@ -7,15 +7,20 @@ const (
 	ActionClockOut     = "off"
 	ActionStartTask    = "starttask"
 	ActionFlex         = "flex"
+	ActionFlexDay      = "flexday" // the day's target is covered by the flex balance
 	ActionTarget       = "target"
 	ActionOutputFormat = "outputformat"
 	ActionSetDay       = "setday"
+	ActionRangeStart   = "rangestart" // always followed by an ActionRangeEnd
+	ActionRangeEnd     = "rangeend"
+	ActionTaskDuration = "taskduration" // a task with a duration but no timestamps
 )
 
 type Entry struct {
 	Action     string // the action to perform based on the interpretation of the command
 	Command    string // the actual command used on the original line
 	Task       string // optional task name
+	Note       string // optional free-text note for the task
 	Timestamp  *time.Time
 	Duration   *time.Duration
 	LineNumber int
@@ -8,16 +8,24 @@ import (
 
 const (
 	Uncategorized = "uncategorized"
+	NormalRate    = "normal"
 )
 
 type Summary struct {
 	Valid         bool
 	ValidationMsg string
 	TimeWorked    time.Duration
+	Target        time.Duration
 	TimeLeft      *time.Duration
 	Surplus       *time.Duration
 	FullDayAt     *time.Time
+	FlexUsed      time.Duration // flex balance spent toward the day, including flex days
+	FlexEarned    time.Duration // time moved into the flex balance by negative flex entries
+	BreakTime     time.Duration // time logged in break categories
+	PaidBreakTime time.Duration // the part of BreakTime that counts as work
 	Categories    []ResultCategory
+	RateClasses   []RateClass   // the time worked per rate class, if rate windows are configured
+	WeightedTime  time.Duration // the time worked, weighted by the rate multipliers
 	Date          *Date
 	Warnings      []string
 }
@@ -33,6 +41,12 @@ func (sd *Date) String() string {
 	return fmt.Sprintf("%04d-%02d-%02d", sd.Year, sd.Month, sd.Day)
 }
 
+type RateClass struct {
+	Name       string
+	Multiplier float64
+	TimeWorked time.Duration
+}
+
 type ResultCategory struct {
 	Name       string
 	TimeWorked time.Duration
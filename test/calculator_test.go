@@ -1,12 +1,15 @@
 package test
 
 import (
+	"fmt"
 	"log"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sporadisk/clocker/calculator"
 	"github.com/sporadisk/clocker/client/logfile"
+	"github.com/sporadisk/clocker/config"
 	"github.com/sporadisk/clocker/event"
 	"github.com/sporadisk/clocker/format"
 	"github.com/sporadisk/clocker/summary"
@@ -83,6 +86,180 @@ func TestCalculate(t *testing.T) {
 			expectEvent("Combat", "The Black Knight", 0, 15).
 			expectEvent("Debate", "The French Taunter", 0, 20).
 			expectEvent("Travel", "Castle Aarrgh", 0, 40),
+		newCalcTest("time ranges between point entries", true, `
+			-- friday 17.10.2025
+			12:00 - Dev: parser
+			14:00 - Break
+			08:00-09:30 Meeting: sprint review
+			09:30 - 10:00 Support: customer call
+			15:00 - Dev: exporter
+		`).expectTimeLeft("3h 30m").
+			expectFullDay("18:30").
+			expectCategory("meeting", "1h 30m").
+			expectCategory("dev", "2h").
+			expectEventCount(3).
+			expectEvent("Support", "customer call", 0, 30),
+		newCalcTest("duration-only tasks", true, `
+			Meeting: standup 15m
+			Meeting: retro +30m
+			+45m Support: customer call
+			Note: rebooted the server after 5m
+			08:00 - Support: tickets
+			12:00 - Stop
+		`).expectTimeLeft("2h").
+			expectCategory("support", "4h 45m").
+			expectCategory("meeting", "45m").
+			expectEventCount(4).
+			expectEvent("Meeting", "standup", 0, 15).
+			expectEvent("Support", "customer call", 0, 45),
+		newCalcTest("negative flex", true, `
+			Flex: -30m
+			08:00 - Start
+			16:00 - End
+		`).expectTimeWorked("7h 30m").
+			expectFlex("0m", "30m").
+			expectEventCount(1),
+		newCalcTest("flex day", true, `
+			Target: 6h
+			Flex day
+		`).expectTimeWorked("6h").
+			expectFlex("6h", "0m"),
+		newCalcTest("partial flex day", true, `
+			Flex day
+			Flex: -2h
+			08:00 - Start
+			10:00 - End
+		`).expectTimeWorked("7h 30m").
+			expectFlex("7h 30m", "2h").
+			expectEventCount(1),
+		newCalcTest("flex day after clock-in", false, `
+			08:00 - Start
+			Flex day
+		`),
+		newCalcTest("paid and unpaid breaks", true, `
+			08:00 - Dev: parser
+			11:30 - Lunch
+			12:15 - Dev: parser
+			14:00 - Personal: dentist
+			15:00 - Dev: review
+			16:00 - End
+		`).withBreaks("lunch: 30m", "personal").
+			expectTimeWorked("6h 45m").
+			expectBreaks("1h 45m", "30m").
+			expectCategory("dev", "6h 15m").
+			expectEventCount(3),
+		newCalcTest("paid breaks on each day", true, `
+			-- Thursday 16.10.2025
+			08:00 - Dev: parser
+			11:30 - Lunch
+			12:00 - Dev: parser
+			15:00 - End
+			-- Friday 17.10.2025
+			08:00 - Dev: parser
+			11:30 - Lunch
+			12:00 - Dev: parser
+			15:00 - End
+		`).withBreaks("lunch: 30m").
+			expectBreaks("1h", "1h").
+			expectEventCount(4),
+		newCalcTest("exported breaks", true, `
+			08:00 - Dev: parser
+			11:30 - Lunch
+			12:15 - End
+			+15m Lunch
+		`).withBreaks("lunch: 30m", "export").
+			expectTimeWorked("4h").
+			expectBreaks("1h", "30m").
+			expectEventCount(3),
+		newCalcTest("break rules satisfied", true, `
+			08:00 - Start
+			11:30 - Break
+			11:40 - Back
+			12:00 - Pause
+			12:30 - Back
+			16:00 - End
+		`).withPolicy(calculator.WorkPolicy{
+			BreakRules:     []calculator.BreakRule{{After: 6 * time.Hour, MinBreak: 30 * time.Minute}},
+			MaxContinuous:  5 * time.Hour,
+			MinBreakLength: 15 * time.Minute,
+		}).expectTimeWorked("7h 20m").
+			expectEventCount(3),
+		newCalcTest("break rules violated", true, `
+			07:00 - Start
+			13:00 - Break
+			13:10 - Back
+			14:00 - End
+		`).withPolicy(calculator.WorkPolicy{
+			BreakRules:     []calculator.BreakRule{{After: 6 * time.Hour, MinBreak: 30 * time.Minute}},
+			MaxContinuous:  5 * time.Hour,
+			MinBreakLength: 15 * time.Minute,
+		}).expectTimeWorked("6h 50m").
+			expectEventCount(2).
+			expectWarnings(
+				"Worked 6h 50m without a break from 07:00 to 14:00: The maximum is 5h",
+				"Worked 6h 50m with no time of breaks: At least 30m of breaks is required after 6h of work",
+			),
+		newCalcTest("automatic lunch deduction", true, `
+			08:00 - Dev: parser
+			12:00 - Lunch
+			12:10 - Dev: review
+			16:00 - End
+		`).withBreaks("lunch").
+			withPolicy(calculator.WorkPolicy{
+				BreakRules: []calculator.BreakRule{{After: 6 * time.Hour, MinBreak: 30 * time.Minute}},
+				AutoLunch:  &calculator.AutoLunch{After: 6 * time.Hour, Duration: 30 * time.Minute},
+			}).
+			expectTimeWorked("7h 30m").
+			expectBreaks("10m", "0m").
+			expectCategory("dev", "7h 30m").
+			expectEventCount(2).
+			expectEvent("Dev", "parser", 4, 0).
+			expectEvent("Dev", "review", 3, 30).
+			expectWarnings("Less than 30m of breaks logged after 6h of work: Deducted 20m for lunch"),
+		newCalcTest("lunch deduction longer than the session", true, `
+			08:00 - Dev: parser
+			13:55 - Support: tickets
+			14:10 - End
+		`).withPolicy(calculator.WorkPolicy{
+			AutoLunch: &calculator.AutoLunch{After: 6 * time.Hour, Duration: 30 * time.Minute},
+		}).
+			expectTimeWorked("5h 40m").
+			expectCategory("dev", "5h 40m").
+			expectEventCount(1).
+			expectEvent("Dev", "parser", 5, 40).
+			expectWarnings("Less than 30m of breaks logged after 6h of work: Deducted 30m for lunch"),
+		newCalcTest("time range overlapping a session", false, `
+			08:00 - Start
+			10:00 - Break
+			09:30-11:00 Meeting: planning
+		`),
+		newCalcTest("time range overlapping the open session", false, `
+			08:00 - Start
+			07:30-08:30 Meeting: planning
+		`),
+		newCalcTest("session overlapping a time range", false, `
+			09:00-10:00 Meeting: planning
+			08:00 - Start
+			11:00 - End
+		`),
+		newCalcTest("rate windows", true, `
+			-- friday 01.03.2024
+			14:00 - Dev: Migration
+			20:00 - Done
+			21:00 - Standby: Phone
+			23:00 - Done
+		`).withRates(testRates()).
+			expectTimeWorked("8h").
+			expectRates("7h 30m", "normal: 4h", "evening: 2h", "standby: 2h").
+			expectEventCount(2),
+		newCalcTest("weekend rate", true, `
+			-- saturday 02.03.2024
+			10:00 - Dev: Hotfix
+			12:00 - Done
+		`).withRates(testRates()).
+			expectTimeWorked("2h").
+			expectRates("4h", "weekend: 2h").
+			expectEventCount(1),
 	}
 
 	lp := logfile.LogParser{}
@@ -104,6 +281,9 @@ func TestCalculate(t *testing.T) {
 			summary := &calculator.LogSummary{
 				Entries: entries,
 				FullDay: defaultFullDay,
+				Breaks:  test.breaks,
+				Policy:  test.policy,
+				Rates:   test.rates,
 			}
 			calcResult, eventResult := summary.Sum()
 			if calcResult.Valid != test.expectSum.Valid {
@@ -125,6 +305,52 @@ func TestCalculate(t *testing.T) {
 				}
 			}
 
+			if test.expectSum.TimeWorked != 0 && test.expectSum.TimeWorked != calcResult.TimeWorked {
+				t.Errorf("timeWorked mismatch: expected %s, got %s", test.expectSum.TimeWorked.String(), calcResult.TimeWorked.String())
+			}
+
+			if test.checkFlex {
+				if test.expectSum.FlexUsed != calcResult.FlexUsed {
+					t.Errorf("flexUsed mismatch: expected %s, got %s", test.expectSum.FlexUsed.String(), calcResult.FlexUsed.String())
+				}
+				if test.expectSum.FlexEarned != calcResult.FlexEarned {
+					t.Errorf("flexEarned mismatch: expected %s, got %s", test.expectSum.FlexEarned.String(), calcResult.FlexEarned.String())
+				}
+			}
+
+			if test.expectSum.BreakTime != calcResult.BreakTime {
+				t.Errorf("breakTime mismatch: expected %s, got %s", test.expectSum.BreakTime.String(), calcResult.BreakTime.String())
+			}
+
+			if test.expectSum.PaidBreakTime != calcResult.PaidBreakTime {
+				t.Errorf("paidBreakTime mismatch: expected %s, got %s", test.expectSum.PaidBreakTime.String(), calcResult.PaidBreakTime.String())
+			}
+
+			if len(test.warnings) != len(calcResult.Warnings) {
+				t.Errorf("warning count mismatch: expected %d, got %d: %q", len(test.warnings), len(calcResult.Warnings), calcResult.Warnings)
+			} else {
+				for i, w := range test.warnings {
+					if w != calcResult.Warnings[i] {
+						t.Errorf("warning mismatch:\nExpected: %s\nGot     : %s", w, calcResult.Warnings[i])
+					}
+				}
+			}
+
+			if test.expectSum.WeightedTime != calcResult.WeightedTime {
+				t.Errorf("weightedTime mismatch: expected %s, got %s", test.expectSum.WeightedTime.String(), calcResult.WeightedTime.String())
+			}
+
+			if len(test.rateClasses) != len(calcResult.RateClasses) {
+				t.Errorf("rate class count mismatch: expected %d, got %d: %v", len(test.rateClasses), len(calcResult.RateClasses), calcResult.RateClasses)
+			} else {
+				for i, rc := range test.rateClasses {
+					ac := calcResult.RateClasses[i]
+					if rc.Name != ac.Name || rc.TimeWorked != ac.TimeWorked {
+						t.Errorf("rate class mismatch: expected %s %s, got %s %s", rc.Name, rc.TimeWorked.String(), ac.Name, ac.TimeWorked.String())
+					}
+				}
+			}
+
 			if test.expectSum.TimeLeft != nil {
 				if calcResult.TimeLeft == nil {
 					t.Errorf("expected timeLeft, but got nil")
@@ -222,6 +448,12 @@ type calcTest struct {
 	expectSum    summary.Summary
 	expectEvents []event.Event
 	eventCount   int
+	checkFlex    bool
+	breaks       calculator.BreakPolicy
+	policy       calculator.WorkPolicy
+	warnings     []string
+	rates        calculator.RateTable
+	rateClasses  []summary.RateClass
 }
 
 func newCalcTest(name string, valid bool, input string) *calcTest {
@@ -302,7 +534,191 @@ func (ct *calcTest) expectEvent(category, task string, hours, minutes int) *calc
 	return ct
 }
 
+func (ct *calcTest) expectFlex(used, earned string) *calcTest {
+	u, err := format.ParseDuration(used)
+	if err != nil {
+		log.Panicf(`failed to parse duration string "%s": %s`, used, err.Error())
+	}
+	e, err := format.ParseDuration(earned)
+	if err != nil {
+		log.Panicf(`failed to parse duration string "%s": %s`, earned, err.Error())
+	}
+	ct.expectSum.FlexUsed = u
+	ct.expectSum.FlexEarned = e
+	ct.checkFlex = true
+	return ct
+}
+
+// withBreaks sets up break categories, as "category" or "category: paid".
+// The special value "export" makes break events exportable.
+func (ct *calcTest) withBreaks(categories ...string) *calcTest {
+	ct.breaks.Paid = map[string]time.Duration{}
+	for _, c := range categories {
+		if c == "export" {
+			ct.breaks.Export = true
+			continue
+		}
+
+		name, paid, found := strings.Cut(c, ":")
+		ct.breaks.Categories = append(ct.breaks.Categories, name)
+		if found {
+			d, err := format.ParseDuration(paid)
+			if err != nil {
+				log.Panicf(`failed to parse duration string "%s": %s`, paid, err.Error())
+			}
+			ct.breaks.Paid[name] = d
+		}
+	}
+	return ct
+}
+
+func (ct *calcTest) expectBreaks(total, paid string) *calcTest {
+	t, err := format.ParseDuration(total)
+	if err != nil {
+		log.Panicf(`failed to parse duration string "%s": %s`, total, err.Error())
+	}
+	p, err := format.ParseDuration(paid)
+	if err != nil {
+		log.Panicf(`failed to parse duration string "%s": %s`, paid, err.Error())
+	}
+	ct.expectSum.BreakTime = t
+	ct.expectSum.PaidBreakTime = p
+	return ct
+}
+
+func (ct *calcTest) withPolicy(policy calculator.WorkPolicy) *calcTest {
+	ct.policy = policy
+	return ct
+}
+
+func (ct *calcTest) expectWarnings(warnings ...string) *calcTest {
+	ct.warnings = warnings
+	return ct
+}
+
 func (ct *calcTest) expectEventCount(count int) *calcTest {
 	ct.eventCount = count
 	return ct
 }
+
+func (ct *calcTest) withRates(rates calculator.RateTable) *calcTest {
+	ct.rates = rates
+	return ct
+}
+
+// expectRates takes the weighted time, and the time per rate class as
+// "name: duration", in order.
+func (ct *calcTest) expectRates(weighted string, classes ...string) *calcTest {
+	w, err := format.ParseDuration(weighted)
+	if err != nil {
+		log.Panicf(`failed to parse duration string "%s": %s`, weighted, err.Error())
+	}
+	ct.expectSum.WeightedTime = w
+
+	for _, c := range classes {
+		name, dur, _ := strings.Cut(c, ":")
+		d, err := format.ParseDuration(strings.TrimSpace(dur))
+		if err != nil {
+			log.Panicf(`failed to parse duration string "%s": %s`, dur, err.Error())
+		}
+		ct.rateClasses = append(ct.rateClasses, summary.RateClass{Name: name, TimeWorked: d})
+	}
+	return ct
+}
+
+func testRates() calculator.RateTable {
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	return calculator.RateTable{
+		Windows: []calculator.RateWindow{
+			{Name: "evening", Days: weekdays, From: 18 * time.Hour, To: 6 * time.Hour, Multiplier: 1.5},
+			{Name: "weekend", Days: []time.Weekday{time.Saturday, time.Sunday}, Multiplier: 2},
+			{Name: "standby", Category: "standby", Multiplier: 0.25},
+		},
+	}
+}
+
+// TestProcessDays checks that logs spanning several days are summed up one
+// day at a time.
+func TestLoadRateTable(t *testing.T) {
+	conf := &config.CalcConfig{Rates: []config.RateConfig{
+		{Name: "evening", From: "18:00", To: "22:00", Multiplier: 1.5},
+		{Name: "Evening", From: "22:00", To: "06:00", Multiplier: 2},
+	}}
+	_, err := calculator.LoadRateTable(conf)
+	if err == nil {
+		t.Errorf("expected an error for a rate name used twice")
+	}
+
+	conf.Rates[1].Name = "night"
+	rates, err := calculator.LoadRateTable(conf)
+	if err != nil {
+		t.Fatalf("calculator.LoadRateTable: %s", err)
+	}
+	if len(rates.Windows) != 2 {
+		t.Errorf("expected 2 rate windows, got %d", len(rates.Windows))
+	}
+}
+
+func TestProcessDays(t *testing.T) {
+	logs := []struct {
+		dialect string
+		text    string
+	}{
+		{"org", `
+* Project Apollo
+  CLOCK: [2025-10-16 Thu 08:00]--[2025-10-16 Thu 11:00] =>  3:00
+  CLOCK: [2025-10-17 Fri 09:00]--[2025-10-17 Fri 11:00] =>  2:00
+`},
+		{"timeclock", `
+i 2025/10/16 08:00:00 dev
+o 2025/10/16 11:00:00
+i 2025/10/17 09:00:00 dev
+o 2025/10/17 11:00:00
+`},
+		{"timewarrior", fmt.Sprintf("inc %s - %s # dev\ninc %s - %s # dev\n",
+			utcStamp(2025, 10, 16, 8), utcStamp(2025, 10, 16, 11),
+			utcStamp(2025, 10, 17, 9), utcStamp(2025, 10, 17, 11)),
+		},
+	}
+
+	for _, tc := range logs {
+		dialect, err := logfile.NewDialect(tc.dialect, logfile.DialectOptions{})
+		if err != nil {
+			t.Fatalf("NewDialect: %s", err)
+		}
+
+		out := &capturedOutput{}
+		calc := &calculator.Calculator{
+			SummaryOutput:  out,
+			DefaultFullDay: 450 * time.Minute,
+		}
+
+		err = calc.Process(dialect.Parse(tc.text))
+		if err != nil {
+			t.Fatalf("%s: calc.Process: %s", tc.dialect, err)
+		}
+
+		if len(out.summaries) != 2 {
+			t.Fatalf("%s: expected 2 summaries, got %d", tc.dialect, len(out.summaries))
+		}
+
+		expected := []struct {
+			date   string
+			worked time.Duration
+		}{
+			{"2025-10-16", 3 * time.Hour},
+			{"2025-10-17", 2 * time.Hour},
+		}
+		for i, e := range expected {
+			sum := out.summaries[i]
+			if !sum.Valid || sum.Date.String() != e.date || sum.TimeWorked != e.worked {
+				t.Errorf("%s: summary %d: expected %s on %s, got %s (%s)", tc.dialect, i, e.worked, e.date, sum.TimeWorked, sum.ValidationMsg)
+			}
+		}
+	}
+}
+
+// utcStamp formats a local time the way Timewarrior stores it.
+func utcStamp(year, month, day, hour int) string {
+	return time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.Local).UTC().Format("20060102T150405Z")
+}
@@ -0,0 +1,151 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/calculator"
+	"github.com/sporadisk/clocker/client/timeclock"
+	"github.com/sporadisk/clocker/event"
+	"github.com/sporadisk/clocker/summary"
+)
+
+func processTestEvents() []*event.Event {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	events := []*event.Event{
+		{Start: at(1, 8, 0), End: at(1, 10, 0), Category: "dev", Task: "api"},
+		{Start: at(1, 10, 0), End: at(1, 11, 0), Category: "dev", Task: "api", Note: "review"},
+		{Start: at(1, 11, 0), End: at(1, 11, 3), Category: "mail"},
+		{Start: at(1, 12, 0), End: at(1, 13, 0), Category: "dev", Task: "api"},
+		{Start: at(1, 22, 0), End: at(2, 2, 0), Category: "ops", Task: "deploy"},
+	}
+	for _, e := range events {
+		e.Date = event.EventDate{Day: 1, Month: 3, Year: 2024}
+		e.DetermineHours()
+	}
+	return events
+}
+
+func TestEventProcessing(t *testing.T) {
+	tests := []struct {
+		name       string
+		processing calculator.EventProcessing
+		expect     []time.Duration
+	}{
+		{"adjacent", calculator.EventProcessing{Merge: calculator.MergeAdjacent},
+			[]time.Duration{3 * time.Hour, 3 * time.Minute, time.Hour, 4 * time.Hour}},
+		{"task", calculator.EventProcessing{Merge: calculator.MergeTask},
+			[]time.Duration{4 * time.Hour, 3 * time.Minute, 4 * time.Hour}},
+		{"min duration", calculator.EventProcessing{MinDuration: 5 * time.Minute},
+			[]time.Duration{2 * time.Hour, time.Hour, time.Hour, 4 * time.Hour}},
+		{"split at midnight", calculator.EventProcessing{SplitAt: []time.Duration{0}},
+			[]time.Duration{2 * time.Hour, time.Hour, 3 * time.Minute, time.Hour, 2 * time.Hour, 2 * time.Hour}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			events := processTestEvents()
+			result := tc.processing.Process(events)
+			if len(result) != len(tc.expect) {
+				t.Fatalf("expected %d events, got %d", len(tc.expect), len(result))
+			}
+
+			for i, e := range result {
+				if e.Duration() != tc.expect[i] {
+					t.Errorf("event %d: expected %s, got %s", i, tc.expect[i], e.Duration())
+				}
+			}
+
+			if events[0].Duration() != 2*time.Hour {
+				t.Errorf("the original events were modified")
+			}
+		})
+	}
+
+	split := (&calculator.EventProcessing{SplitAt: []time.Duration{0}}).Process(processTestEvents())
+	if split[5].Date.Day != 2 {
+		t.Errorf("expected the part after midnight to be dated the 2nd, got the %d.", split[5].Date.Day)
+	}
+
+	merged := (&calculator.EventProcessing{Merge: calculator.MergeTask}).Process(processTestEvents())
+	if merged[0].Note != "review" {
+		t.Errorf("expected the merged note %q, got %q", "review", merged[0].Note)
+	}
+
+	// merged across the gap at 11:00, so it would overlap the mail event
+	if !merged[0].Start.IsZero() || !merged[0].End.IsZero() {
+		t.Errorf("expected the event merged across a gap to lose its times, got %s - %s", merged[0].Start, merged[0].End)
+	}
+
+	adjacent := (&calculator.EventProcessing{Merge: calculator.MergeAdjacent}).Process(processTestEvents())
+	if adjacent[0].Start.Hour() != 8 || adjacent[0].End.Hour() != 11 {
+		t.Errorf("expected the adjacent events to be merged into 08-11, got %s - %s", adjacent[0].Start, adjacent[0].End)
+	}
+}
+
+func TestProcessSummary(t *testing.T) {
+	sum := &summary.Summary{
+		TimeWorked: 8*time.Hour + 3*time.Minute,
+		Categories: []summary.ResultCategory{
+			{Name: "Dev", TimeWorked: 4 * time.Hour},
+			{Name: "mail", TimeWorked: 3 * time.Minute},
+			{Name: "ops", TimeWorked: 4 * time.Hour},
+		},
+	}
+
+	processing := &calculator.EventProcessing{MinDuration: 5 * time.Minute}
+	events := processing.ProcessSummary(sum, processTestEvents())
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(events))
+	}
+
+	if len(sum.Categories) != 2 || sum.Categories[0].Name != "Dev" || sum.Categories[1].Name != "ops" {
+		t.Errorf("expected the mail category to be dropped along with its event, got %v", sum.Categories)
+	}
+	if sum.TimeWorked != 8*time.Hour+3*time.Minute {
+		t.Errorf("expected the time worked to be left as it is, got %s", sum.TimeWorked)
+	}
+}
+
+// TestExportMergedTask checks that a task merged across a break keeps its
+// times for the exporters that need them.
+func TestExportMergedTask(t *testing.T) {
+	merged := (&calculator.EventProcessing{Merge: calculator.MergeTask}).Process(processTestEvents())
+	if len(merged[0].Parts) != 2 {
+		t.Fatalf("expected the merged event to keep 2 timed parts, got %d", len(merged[0].Parts))
+	}
+
+	path := filepath.Join(t.TempDir(), "work.timeclock")
+	exporter := &timeclock.Client{Path: path}
+	err := exporter.Init()
+	if err != nil {
+		t.Fatalf("Init: %s", err)
+	}
+
+	err = exporter.Export(merged)
+	if err != nil {
+		t.Fatalf("Export: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %s", err)
+	}
+
+	for _, expect := range []string{
+		"i 2024/03/01 08:00:00 dev  api",
+		"o 2024/03/01 11:00:00",
+		"i 2024/03/01 12:00:00 dev  api",
+		"o 2024/03/01 13:00:00",
+	} {
+		if !strings.Contains(string(data), expect) {
+			t.Errorf("expected %q in:\n%s", expect, data)
+		}
+	}
+}
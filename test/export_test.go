@@ -0,0 +1,67 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/sporadisk/clocker/calculator"
+	"github.com/sporadisk/clocker/config"
+	"github.com/sporadisk/clocker/event"
+)
+
+type recordingExporter struct {
+	events []*event.Event
+}
+
+func (r *recordingExporter) Export(events []*event.Event) error {
+	r.events = append(r.events, events...)
+	return nil
+}
+
+func init() {
+	event.RegisterExporter(event.ExporterSpec{
+		Name: "test-recorder",
+		Factory: func(params map[string]string) (event.Exporter, error) {
+			return &recordingExporter{}, nil
+		},
+	})
+}
+
+func TestExportTargets(t *testing.T) {
+	conf := &config.Config{
+		Exporter: &config.ExporterConfig{Name: "test-recorder", Label: "archive"},
+		Exporters: []*config.ExporterConfig{
+			{Name: "test-recorder", Label: "project a", Categories: []string{"Client-*"}},
+			{Name: "test-recorder", Label: "project b", Categories: []string{"internal"}},
+		},
+	}
+
+	targets, err := calculator.LoadExportTargets(conf)
+	if err != nil {
+		t.Fatalf("LoadExportTargets: %s", err)
+	}
+
+	if len(targets) != 3 {
+		t.Fatalf("expected 3 targets, got %d", len(targets))
+	}
+
+	events := []*event.Event{
+		{Category: "client-acme"},
+		{Category: "client-initech"},
+		{Category: "internal"},
+		{Category: "admin"},
+	}
+
+	expect := map[string]int{"archive": 4, "project a": 2, "project b": 1}
+	for _, target := range targets {
+		matched := target.Events(events)
+		if len(matched) != expect[target.Label] {
+			t.Errorf("%s: expected %d events, got %d", target.Label, expect[target.Label], len(matched))
+		}
+	}
+
+	conf.Exporters = append(conf.Exporters, &config.ExporterConfig{Name: "test-recorder", Label: "archive"})
+	_, err = calculator.LoadExportTargets(conf)
+	if err == nil {
+		t.Errorf("expected an error for a duplicate label")
+	}
+}
@@ -0,0 +1,160 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sporadisk/clocker/calculator"
+	"github.com/sporadisk/clocker/client/logfile"
+	"github.com/sporadisk/clocker/history"
+	"github.com/sporadisk/clocker/summary"
+)
+
+func TestLimits(t *testing.T) {
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("history.Open: %s", err)
+	}
+
+	limits := &calculator.Limits{
+		MaxDay:  9 * time.Hour,
+		MaxWeek: 20 * time.Hour,
+		MinRest: 11 * time.Hour,
+	}
+
+	clock := func(day int, hour int) time.Time {
+		return time.Date(2024, time.March, day, hour, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		day      history.Day
+		warnings int
+	}{
+		{history.Day{Date: "2024-03-04", FirstIn: clock(4, 8), LastOut: clock(4, 16), Worked: 8 * time.Hour}, 0},
+		// over the daily maximum, and only 10 hours of rest after monday
+		{history.Day{Date: "2024-03-05", FirstIn: clock(5, 2), LastOut: clock(5, 12), Worked: 10 * time.Hour}, 2},
+		// over the weekly maximum
+		{history.Day{Date: "2024-03-06", FirstIn: clock(6, 8), LastOut: clock(6, 11), Worked: 3 * time.Hour}, 1},
+		// clocked out after midnight, 7 hours of rest before monday
+		{history.Day{Date: "2024-03-03", FirstIn: clock(3, 20), LastOut: clock(4, 1), Worked: 5 * time.Hour}, 1},
+	}
+
+	for _, tc := range tests {
+		warnings, err := limits.Check(tc.day, store, nil)
+		if err != nil {
+			t.Fatalf("%s: Check: %s", tc.day.Date, err)
+		}
+		if len(warnings) != tc.warnings {
+			t.Errorf("%s: expected %d warnings, got %d: %v", tc.day.Date, tc.warnings, len(warnings), warnings)
+		}
+	}
+}
+
+type capturedOutput struct {
+	summaries []summary.Summary
+}
+
+func (co *capturedOutput) OutputSummary(sum summary.Summary) error {
+	co.summaries = append(co.summaries, sum)
+	return nil
+}
+
+// TestLimitsFromLog checks the limits on days summarized from log files, as
+// the calculator records them.
+func TestLimitsFromLog(t *testing.T) {
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("history.Open: %s", err)
+	}
+
+	lp := logfile.LogParser{}
+	err = lp.Init()
+	if err != nil {
+		t.Fatalf("lp.Init: %s", err)
+	}
+
+	out := &capturedOutput{}
+	calc := &calculator.Calculator{
+		SummaryOutput:  out,
+		DefaultFullDay: 450 * time.Minute,
+		Limits:         &calculator.Limits{MinRest: 11 * time.Hour},
+		History:        store,
+	}
+
+	logs := []string{
+		"-- Thursday 16.10.2025\n15:00 - Dev: release\n23:00 - Stop\n",
+		"-- Friday 17.10.2025\n06:00 - Dev: hotfix\n10:00 - Stop\n",
+	}
+	for _, text := range logs {
+		err = calc.Process(lp.Parse(text))
+		if err != nil {
+			t.Fatalf("calc.Process: %s", err)
+		}
+	}
+
+	if len(out.summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %d", len(out.summaries))
+	}
+
+	expected := "Only 7h of rest between 2025-10-16 23:00 and 2025-10-17 06:00: The minimum is 11h"
+	warnings := out.summaries[1].Warnings
+	if len(warnings) != 1 || warnings[0] != expected {
+		t.Errorf("expected the warning %q, got %v", expected, warnings)
+	}
+}
+
+// TestLimitsWithFlex checks that flex counts toward the target, but not toward
+// the daily and weekly maximums.
+func TestLimitsWithFlex(t *testing.T) {
+	store, err := history.Open(filepath.Join(t.TempDir(), "history.json"))
+	if err != nil {
+		t.Fatalf("history.Open: %s", err)
+	}
+
+	lp := logfile.LogParser{}
+	err = lp.Init()
+	if err != nil {
+		t.Fatalf("lp.Init: %s", err)
+	}
+
+	out := &capturedOutput{}
+	calc := &calculator.Calculator{
+		SummaryOutput:  out,
+		DefaultFullDay: 8 * time.Hour,
+		Limits:         &calculator.Limits{MaxDay: 9 * time.Hour, MaxWeek: 40 * time.Hour},
+		History:        store,
+	}
+
+	logs := []string{
+		"-- Monday 13.10.2025\nFlex day\n",
+		"-- Tuesday 14.10.2025\nFlex day\n",
+		"-- Wednesday 15.10.2025\n08:00 - Dev: parser\n17:00 - Stop\n",
+		"-- Thursday 16.10.2025\n08:00 - Dev: parser\n17:00 - Stop\n",
+		// a long day, topped up with flex
+		"-- Friday 17.10.2025\nFlex: 2h\n08:00 - Dev: release\n16:00 - Stop\n",
+	}
+	for _, text := range logs {
+		err = calc.Process(lp.Parse(text))
+		if err != nil {
+			t.Fatalf("calc.Process: %s", err)
+		}
+	}
+
+	if len(out.summaries) != len(logs) {
+		t.Fatalf("expected %d summaries, got %d", len(logs), len(out.summaries))
+	}
+
+	for i, sum := range out.summaries {
+		if !sum.Valid {
+			t.Errorf("summary %d: %s", i, sum.ValidationMsg)
+		}
+		if len(sum.Warnings) != 0 {
+			t.Errorf("summary %d: expected no warnings, got %v", i, sum.Warnings)
+		}
+	}
+
+	if out.summaries[4].TimeWorked != 10*time.Hour {
+		t.Errorf("expected the flex to count toward the day, got %s", out.summaries[4].TimeWorked)
+	}
+}